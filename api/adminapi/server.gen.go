@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
@@ -26,6 +27,20 @@ const (
 	BotInfoResponseMarkAsReadModeManual BotInfoResponseMarkAsReadMode = "manual"
 )
 
+// Defines values for BotInfoResponseMessageQuotaPlan.
+const (
+	BotInfoResponseMessageQuotaPlanFree     BotInfoResponseMessageQuotaPlan = "free"
+	BotInfoResponseMessageQuotaPlanLight    BotInfoResponseMessageQuotaPlan = "light"
+	BotInfoResponseMessageQuotaPlanNone     BotInfoResponseMessageQuotaPlan = "none"
+	BotInfoResponseMessageQuotaPlanStandard BotInfoResponseMessageQuotaPlan = "standard"
+)
+
+// Defines values for ConversationMessageSender.
+const (
+	SenderBot  ConversationMessageSender = "bot"
+	SenderUser ConversationMessageSender = "user"
+)
+
 // Defines values for CreateBotRequestChatMode.
 const (
 	CreateBotRequestChatModeBot  CreateBotRequestChatMode = "bot"
@@ -38,11 +53,107 @@ const (
 	CreateBotRequestMarkAsReadModeManual CreateBotRequestMarkAsReadMode = "manual"
 )
 
+// Defines values for FollowStateResponseStatus.
+const (
+	Blocked   FollowStateResponseStatus = "blocked"
+	Following FollowStateResponseStatus = "following"
+)
+
+// Defines values for JoinChatRequestType.
+const (
+	ChatTypeGroup JoinChatRequestType = "group"
+	ChatTypeRoom  JoinChatRequestType = "room"
+)
+
+// Defines values for MessageTranscodingResult.
+const (
+	MessageTranscodingResultFailed    MessageTranscodingResult = "failed"
+	MessageTranscodingResultSucceeded MessageTranscodingResult = "succeeded"
+)
+
+// Defines values for SendUserMessageRequestType.
+const (
+	Audio   SendUserMessageRequestType = "audio"
+	File    SendUserMessageRequestType = "file"
+	Image   SendUserMessageRequestType = "image"
+	Sticker SendUserMessageRequestType = "sticker"
+	Text    SendUserMessageRequestType = "text"
+	Video   SendUserMessageRequestType = "video"
+)
+
+// Defines values for SetMessageQuotaPlanRequestPlan.
+const (
+	PlanFree     SetMessageQuotaPlanRequestPlan = "free"
+	PlanLight    SetMessageQuotaPlanRequestPlan = "light"
+	PlanNone     SetMessageQuotaPlanRequestPlan = "none"
+	PlanStandard SetMessageQuotaPlanRequestPlan = "standard"
+)
+
+// Defines values for UserAttributesAppType.
+const (
+	AppTypeAndroid UserAttributesAppType = "android"
+	AppTypeIos     UserAttributesAppType = "ios"
+)
+
+// Defines values for UserAttributesGender.
+const (
+	GenderFemale UserAttributesGender = "female"
+	GenderMale   UserAttributesGender = "male"
+)
+
+// Defines values for UserAttributesResponseAppType.
+const (
+	UserAttributesResponseAppTypeAndroid UserAttributesResponseAppType = "android"
+	UserAttributesResponseAppTypeIos     UserAttributesResponseAppType = "ios"
+)
+
+// Defines values for UserAttributesResponseGender.
+const (
+	UserAttributesResponseGenderFemale UserAttributesResponseGender = "female"
+	UserAttributesResponseGenderMale   UserAttributesResponseGender = "male"
+)
+
+// ApiCall defines model for ApiCall.
+type ApiCall struct {
+	// CreatedAt When the request was handled
+	CreatedAt time.Time `json:"createdAt"`
+
+	// LatencyMs Time taken to handle the request, in milliseconds
+	LatencyMs int `json:"latencyMs"`
+
+	// Method HTTP method of the request
+	Method string `json:"method"`
+
+	// Path Path of the request, including the query string
+	Path string `json:"path"`
+
+	// RequestBody Request body. Not included if the request has no body or a binary body.
+	RequestBody *string `json:"requestBody,omitempty"`
+
+	// RequestHeaders Headers of the request. The Authorization header isn't included.
+	RequestHeaders map[string]string `json:"requestHeaders"`
+
+	// RequestId Request ID returned in the X-Line-Request-Id header
+	RequestId string `json:"requestId"`
+
+	// ResponseBody Response body. Not included if the response has no body or a binary body.
+	ResponseBody *string `json:"responseBody,omitempty"`
+
+	// ResponseHeaders Headers of the response
+	ResponseHeaders map[string]string `json:"responseHeaders"`
+
+	// StatusCode HTTP status code of the response
+	StatusCode int `json:"statusCode"`
+}
+
 // BotInfoResponse defines model for BotInfoResponse.
 type BotInfoResponse struct {
 	// BasicId Bot's basic ID
 	BasicId string `json:"basicId"`
 
+	// ChannelSecret Channel secret used to sign webhook requests with the `X-Line-Signature` header
+	ChannelSecret string `json:"channelSecret"`
+
 	// ChatMode Chat settings set in the LINE Official Account Manager.
 	// - `chat`: Chat is set to "On"
 	// - `bot`: Chat is set to "Off"
@@ -56,6 +167,9 @@ type BotInfoResponse struct {
 	// - `manual`: Auto read setting is disabled
 	MarkAsReadMode BotInfoResponseMarkAsReadMode `json:"markAsReadMode"`
 
+	// MessageQuotaPlan Plan the monthly limit of messages is determined by (`free`, `light`, `standard` or `none`). Bots are created with `none`.
+	MessageQuotaPlan BotInfoResponseMessageQuotaPlan `json:"messageQuotaPlan"`
+
 	// PictureUrl Profile image URL. HTTPS image URL. Not included if the bot doesn't have a profile image.
 	PictureUrl *string `json:"pictureUrl,omitempty"`
 
@@ -64,6 +178,9 @@ type BotInfoResponse struct {
 
 	// UserId Bot's user ID
 	UserId string `json:"userId"`
+
+	// WebhookRedelivery Whether failed webhook deliveries are retried with `deliveryContext.isRedelivery` set to `true`
+	WebhookRedelivery bool `json:"webhookRedelivery"`
 }
 
 // BotInfoResponseChatMode Chat settings set in the LINE Official Account Manager.
@@ -76,11 +193,47 @@ type BotInfoResponseChatMode string
 // - `manual`: Auto read setting is disabled
 type BotInfoResponseMarkAsReadMode string
 
+// BotInfoResponseMessageQuotaPlan Plan the monthly limit of messages is determined by (`free`, `light`, `standard` or `none`). Bots are created with `none`.
+type BotInfoResponseMessageQuotaPlan string
+
+// ConversationMessage defines model for ConversationMessage.
+type ConversationMessage struct {
+	// CreatedAt When the message was sent
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id ID of the message in the conversation
+	Id int32 `json:"id"`
+
+	// Message Message object as sent by the bot, or the message object of the message event for messages sent by the user
+	Message map[string]interface{} `json:"message"`
+
+	// MessageType API the bot sent the message with (reply, push, multicast, narrowcast, broadcast or pnp). Not included for messages sent by the user.
+	MessageType *string `json:"messageType,omitempty"`
+
+	// ReadAt When the bot marked the message as read. Not included for unread messages and messages sent by the bot.
+	ReadAt *time.Time `json:"readAt,omitempty"`
+
+	// Sender Whether the message was sent by the bot or by the user
+	Sender ConversationMessageSender `json:"sender"`
+}
+
+// ConversationMessageSender Whether the message was sent by the bot or by the user
+type ConversationMessageSender string
+
+// ConversationResponse defines model for ConversationResponse.
+type ConversationResponse struct {
+	// Messages Messages in the chat, oldest first
+	Messages []ConversationMessage `json:"messages"`
+}
+
 // CreateBotRequest defines model for CreateBotRequest.
 type CreateBotRequest struct {
 	// BasicId Bot's basic ID
 	BasicId *string `json:"basicId,omitempty"`
 
+	// ChannelSecret Channel secret used to sign webhook requests. Generated if not specified.
+	ChannelSecret *string `json:"channelSecret,omitempty"`
+
 	// ChatMode Chat settings set in the LINE Official Account Manager.
 	// - `chat`: Chat is set to "On"
 	// - `bot`: Chat is set to "Off"
@@ -102,6 +255,9 @@ type CreateBotRequest struct {
 
 	// UserId Bot's user ID
 	UserId *string `json:"userId,omitempty"`
+
+	// WebhookRedelivery Whether failed webhook deliveries are retried. Defaults to `false`.
+	WebhookRedelivery *bool `json:"webhookRedelivery,omitempty"`
 }
 
 // CreateBotRequestChatMode Chat settings set in the LINE Official Account Manager.
@@ -140,6 +296,21 @@ type ErrorResponse struct {
 	} `json:"error"`
 }
 
+// FollowStateResponse defines model for FollowStateResponse.
+type FollowStateResponse struct {
+	// ReplyToken Reply token included in the `follow` webhook event. Not included for `unfollow` events.
+	ReplyToken *string `json:"replyToken,omitempty"`
+
+	// Status Relationship between the user and the bot after the operation
+	Status FollowStateResponseStatus `json:"status"`
+
+	// WebhookEventId ID of the queued `follow` or `unfollow` webhook event
+	WebhookEventId string `json:"webhookEventId"`
+}
+
+// FollowStateResponseStatus Relationship between the user and the bot after the operation
+type FollowStateResponseStatus string
+
 // FollowerProfile defines model for FollowerProfile.
 type FollowerProfile struct {
 	// DisplayName Display name of the follower
@@ -158,20 +329,323 @@ type FollowerProfile struct {
 	UserId string `json:"userId"`
 }
 
+// JoinChatRequest defines model for JoinChatRequest.
+type JoinChatRequest struct {
+	// Type Type of the chat the bot joins
+	Type JoinChatRequestType `json:"type"`
+}
+
+// JoinChatRequestType Type of the chat the bot joins
+type JoinChatRequestType string
+
+// JoinChatResponse defines model for JoinChatResponse.
+type JoinChatResponse struct {
+	// ChatId Group ID or room ID of the chat
+	ChatId string `json:"chatId"`
+
+	// ReplyToken Reply token included in the `join` webhook event
+	ReplyToken string `json:"replyToken"`
+
+	// WebhookEventId ID of the queued `join` webhook event
+	WebhookEventId string `json:"webhookEventId"`
+}
+
+// LoadingAnimationResponse defines model for LoadingAnimationResponse.
+type LoadingAnimationResponse struct {
+	// Active Whether the loading animation is shown in the chat
+	Active bool `json:"active"`
+
+	// ExpiresAt When the animation stops unless the bot sends a message earlier. Only included while the animation is shown.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// LoadingSeconds Number of seconds the animation was started for. Only included while the animation is shown.
+	LoadingSeconds *int32 `json:"loadingSeconds,omitempty"`
+
+	// StartedAt When the bot started the animation. Only included while the animation is shown.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+}
+
+// MessageTranscoding How the transcoding of a video or audio proceeds. Can be specified only when `type` is `video` or `audio`.
+// The content is `processing` for `processingSeconds` after it is sent and then ends up in the `result` state.
+// The content of other messages, and of videos and audio without this setting, is ready as soon as it is sent.
+type MessageTranscoding struct {
+	// ProcessingSeconds How long the content stays in the `processing` state
+	ProcessingSeconds *int `json:"processingSeconds,omitempty"`
+
+	// Result Status the transcoding ends up in
+	Result *MessageTranscodingResult `json:"result,omitempty"`
+}
+
+// MessageTranscodingResult Status the transcoding ends up in
+type MessageTranscodingResult string
+
+// PhoneNumberRequest defines model for PhoneNumberRequest.
+type PhoneNumberRequest struct {
+	// PhoneNumber Phone number in E.164 format
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// PhoneNumberResponse defines model for PhoneNumberResponse.
+type PhoneNumberResponse struct {
+	// PhoneNumber Phone number in E.164 format
+	PhoneNumber string `json:"phoneNumber"`
+
+	// PhoneNumberHash Hex encoded SHA-256 hash of the phone number, which is specified in `to` of PNP messages
+	PhoneNumberHash string `json:"phoneNumberHash"`
+
+	// UserId User ID
+	UserId string `json:"userId"`
+}
+
+// SendUserMessageRequest defines model for SendUserMessageRequest.
+type SendUserMessageRequest struct {
+	// Content Base64-encoded content of the message. Required when `type` is `image`, `video`, `audio` or `file`.
+	// Images must be JPEG, PNG or GIF.
+	Content *[]byte `json:"content,omitempty"`
+
+	// ContentType Content-Type the content is served with. Detected from the content if not specified.
+	// Ignored for images, whose Content-Type is determined by their format.
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Duration Length of the video or audio in milliseconds
+	Duration *int64 `json:"duration,omitempty"`
+
+	// FileName File name. Required when `type` is `file`.
+	FileName *string `json:"fileName,omitempty"`
+
+	// PackageId Package ID of the sticker. Required when `type` is `sticker`.
+	PackageId *string `json:"packageId,omitempty"`
+
+	// PreviewImage Base64-encoded JPEG, PNG or GIF image the preview of a video is generated from.
+	// A blank preview image is served if not specified.
+	PreviewImage *[]byte `json:"previewImage,omitempty"`
+
+	// StickerId Sticker ID. Required when `type` is `sticker`.
+	StickerId *string `json:"stickerId,omitempty"`
+
+	// Text Message text. Required when `type` is `text`.
+	Text *string `json:"text,omitempty"`
+
+	// Transcoding How the transcoding of a video or audio proceeds. Can be specified only when `type` is `video` or `audio`.
+	// The content is `processing` for `processingSeconds` after it is sent and then ends up in the `result` state.
+	// The content of other messages, and of videos and audio without this setting, is ready as soon as it is sent.
+	Transcoding *MessageTranscoding `json:"transcoding,omitempty"`
+
+	// Type Type of the message sent by the user
+	Type SendUserMessageRequestType `json:"type"`
+}
+
+// SendUserMessageRequestType Type of the message sent by the user
+type SendUserMessageRequestType string
+
+// SendUserMessageResponse defines model for SendUserMessageResponse.
+type SendUserMessageResponse struct {
+	// MessageId ID of the message sent by the user
+	MessageId string `json:"messageId"`
+
+	// QuoteToken Quote token of the message sent by the user. Not issued for audio and file messages.
+	QuoteToken *string `json:"quoteToken,omitempty"`
+
+	// ReplyToken Reply token included in the webhook event
+	ReplyToken string `json:"replyToken"`
+
+	// WebhookEventId ID of the webhook event sent to the bot
+	WebhookEventId string `json:"webhookEventId"`
+}
+
+// SetMessageQuotaPlanRequest defines model for SetMessageQuotaPlanRequest.
+type SetMessageQuotaPlanRequest struct {
+	// Plan Plan the monthly limit of messages sent with the push, multicast, narrowcast and broadcast APIs is determined by.
+	// - `free`: 200 messages
+	// - `light`: 5,000 messages
+	// - `standard`: 30,000 messages
+	// - `none`: No limit
+	Plan SetMessageQuotaPlanRequestPlan `json:"plan"`
+}
+
+// SetMessageQuotaPlanRequestPlan Plan the monthly limit of messages sent with the push, multicast, narrowcast and broadcast APIs is determined by.
+// - `free`: 200 messages
+// - `light`: 5,000 messages
+// - `standard`: 30,000 messages
+// - `none`: No limit
+type SetMessageQuotaPlanRequestPlan string
+
+// SetWebhookRedeliveryRequest defines model for SetWebhookRedeliveryRequest.
+type SetWebhookRedeliveryRequest struct {
+	// Enabled Whether to retry failed webhook deliveries
+	Enabled bool `json:"enabled"`
+}
+
+// UserAttributes defines model for UserAttributes.
+type UserAttributes struct {
+	// Age Age of the user
+	Age *int `json:"age,omitempty"`
+
+	// AppType OS of the device the user uses LINE on
+	AppType *UserAttributesAppType `json:"appType,omitempty"`
+
+	// Area Area code of where the user lives, in the format used by narrowcast area filters
+	Area *string `json:"area,omitempty"`
+
+	// Gender Gender of the user
+	Gender *UserAttributesGender `json:"gender,omitempty"`
+}
+
+// UserAttributesAppType OS of the device the user uses LINE on
+type UserAttributesAppType string
+
+// UserAttributesGender Gender of the user
+type UserAttributesGender string
+
+// UserAttributesResponse defines model for UserAttributesResponse.
+type UserAttributesResponse struct {
+	// Age Age of the user. Not included if unknown.
+	Age *int `json:"age,omitempty"`
+
+	// AppType OS of the device the user uses LINE on. Not included if unknown.
+	AppType *UserAttributesResponseAppType `json:"appType,omitempty"`
+
+	// Area Area code of where the user lives. Not included if unknown.
+	Area *string `json:"area,omitempty"`
+
+	// Gender Gender of the user. Not included if unknown.
+	Gender *UserAttributesResponseGender `json:"gender,omitempty"`
+
+	// UserId User ID
+	UserId string `json:"userId"`
+}
+
+// UserAttributesResponseAppType OS of the device the user uses LINE on. Not included if unknown.
+type UserAttributesResponseAppType string
+
+// UserAttributesResponseGender Gender of the user. Not included if unknown.
+type UserAttributesResponseGender string
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// CreatedAt When the request was sent
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Endpoint Webhook URL the request was sent to
+	Endpoint string `json:"endpoint"`
+
+	// Error Reason why the delivery failed. Not included if the delivery succeeded.
+	Error *string `json:"error,omitempty"`
+
+	// Id ID of the webhook delivery
+	Id int32 `json:"id"`
+
+	// LatencyMs Time taken until the bot server responded, in milliseconds
+	LatencyMs int `json:"latencyMs"`
+
+	// RequestBody Request body sent to the bot server, exactly as signed
+	RequestBody string `json:"requestBody"`
+
+	// RequestHeaders Headers sent to the bot server
+	RequestHeaders map[string]string `json:"requestHeaders"`
+
+	// ResponseBody Beginning of the response body returned by the bot server (up to 1024 bytes). Not included if the request didn't reach the bot server.
+	ResponseBody *string `json:"responseBody,omitempty"`
+
+	// StatusCode HTTP status code returned by the bot server. 0 if the request didn't reach the bot server.
+	StatusCode int `json:"statusCode"`
+
+	// Success Whether the bot server responded with a 2xx status code
+	Success bool `json:"success"`
+
+	// WebhookEventId ID of the delivered webhook event. Not included for requests sent by the test webhook endpoint API.
+	WebhookEventId *string `json:"webhookEventId,omitempty"`
+}
+
+// WebhookDeliveryListResponse defines model for WebhookDeliveryListResponse.
+type WebhookDeliveryListResponse struct {
+	// Deliveries Webhook deliveries, newest first
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// WebhookEventId Only return the delivery attempts of this webhook event
+	WebhookEventId *string `form:"webhookEventId,omitempty" json:"webhookEventId,omitempty"`
+
+	// Limit Maximum number of deliveries to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // CreateBotJSONRequestBody defines body for CreateBot for application/json ContentType.
 type CreateBotJSONRequestBody = CreateBotRequest
 
+// JoinChatJSONRequestBody defines body for JoinChat for application/json ContentType.
+type JoinChatJSONRequestBody = JoinChatRequest
+
 // CreateFollowersJSONRequestBody defines body for CreateFollowers for application/json ContentType.
 type CreateFollowersJSONRequestBody = CreateFollowersRequest
 
+// SetMessageQuotaPlanJSONRequestBody defines body for SetMessageQuotaPlan for application/json ContentType.
+type SetMessageQuotaPlanJSONRequestBody = SetMessageQuotaPlanRequest
+
+// SendUserMessageJSONRequestBody defines body for SendUserMessage for application/json ContentType.
+type SendUserMessageJSONRequestBody = SendUserMessageRequest
+
+// SetWebhookRedeliveryJSONRequestBody defines body for SetWebhookRedelivery for application/json ContentType.
+type SetWebhookRedeliveryJSONRequestBody = SetWebhookRedeliveryRequest
+
+// SetUserAttributesJSONRequestBody defines body for SetUserAttributes for application/json ContentType.
+type SetUserAttributesJSONRequestBody = UserAttributes
+
+// SetUserPhoneNumberJSONRequestBody defines body for SetUserPhoneNumber for application/json ContentType.
+type SetUserPhoneNumberJSONRequestBody = PhoneNumberRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Create a new bot
 	// (POST /admin/bots)
 	CreateBot(w http.ResponseWriter, r *http.Request)
+	// Make a bot join a group chat or multi-person chat
+	// (POST /admin/bots/{botId}/chats)
+	JoinChat(w http.ResponseWriter, r *http.Request, botId string)
+	// Get the loading animation in a chat
+	// (GET /admin/bots/{botId}/chats/{chatId}/loading)
+	GetLoadingAnimation(w http.ResponseWriter, r *http.Request, botId string, chatId string)
+	// Get the conversation between a bot and a chat
+	// (GET /admin/bots/{botId}/chats/{chatId}/messages)
+	GetConversation(w http.ResponseWriter, r *http.Request, botId string, chatId string)
 	// Create dummy followers for a bot
 	// (POST /admin/bots/{botId}/followers)
 	CreateFollowers(w http.ResponseWriter, r *http.Request, botId string)
+	// Set the message quota plan of a bot
+	// (PUT /admin/bots/{botId}/message-quota/plan)
+	SetMessageQuotaPlan(w http.ResponseWriter, r *http.Request, botId string)
+	// Make a user block a bot
+	// (POST /admin/bots/{botId}/users/{userId}/block)
+	BlockBot(w http.ResponseWriter, r *http.Request, botId string, userId string)
+	// Make a user follow a bot
+	// (POST /admin/bots/{botId}/users/{userId}/follow)
+	FollowBot(w http.ResponseWriter, r *http.Request, botId string, userId string)
+	// Send a message from a user to a bot
+	// (POST /admin/bots/{botId}/users/{userId}/messages)
+	SendUserMessage(w http.ResponseWriter, r *http.Request, botId string, userId string)
+	// Make a user unblock a bot
+	// (POST /admin/bots/{botId}/users/{userId}/unblock)
+	UnblockBot(w http.ResponseWriter, r *http.Request, botId string, userId string)
+	// List webhook deliveries of a bot
+	// (GET /admin/bots/{botId}/webhook/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, botId string, params ListWebhookDeliveriesParams)
+	// Get a webhook delivery
+	// (GET /admin/bots/{botId}/webhook/deliveries/{deliveryId})
+	GetWebhookDelivery(w http.ResponseWriter, r *http.Request, botId string, deliveryId int32)
+	// Set the webhook redelivery setting of a bot
+	// (PUT /admin/bots/{botId}/webhook/redelivery)
+	SetWebhookRedelivery(w http.ResponseWriter, r *http.Request, botId string)
+	// Get a Messaging API call by request ID
+	// (GET /admin/requests/{requestId})
+	GetApiCall(w http.ResponseWriter, r *http.Request, requestId string)
+	// Set the demographic attributes of a user
+	// (PUT /admin/users/{userId}/attributes)
+	SetUserAttributes(w http.ResponseWriter, r *http.Request, userId string)
+	// Set the phone number of a user
+	// (PUT /admin/users/{userId}/phone-number)
+	SetUserPhoneNumber(w http.ResponseWriter, r *http.Request, userId string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -184,12 +658,96 @@ func (_ Unimplemented) CreateBot(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Make a bot join a group chat or multi-person chat
+// (POST /admin/bots/{botId}/chats)
+func (_ Unimplemented) JoinChat(w http.ResponseWriter, r *http.Request, botId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the loading animation in a chat
+// (GET /admin/bots/{botId}/chats/{chatId}/loading)
+func (_ Unimplemented) GetLoadingAnimation(w http.ResponseWriter, r *http.Request, botId string, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the conversation between a bot and a chat
+// (GET /admin/bots/{botId}/chats/{chatId}/messages)
+func (_ Unimplemented) GetConversation(w http.ResponseWriter, r *http.Request, botId string, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Create dummy followers for a bot
 // (POST /admin/bots/{botId}/followers)
 func (_ Unimplemented) CreateFollowers(w http.ResponseWriter, r *http.Request, botId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Set the message quota plan of a bot
+// (PUT /admin/bots/{botId}/message-quota/plan)
+func (_ Unimplemented) SetMessageQuotaPlan(w http.ResponseWriter, r *http.Request, botId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Make a user block a bot
+// (POST /admin/bots/{botId}/users/{userId}/block)
+func (_ Unimplemented) BlockBot(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Make a user follow a bot
+// (POST /admin/bots/{botId}/users/{userId}/follow)
+func (_ Unimplemented) FollowBot(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Send a message from a user to a bot
+// (POST /admin/bots/{botId}/users/{userId}/messages)
+func (_ Unimplemented) SendUserMessage(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Make a user unblock a bot
+// (POST /admin/bots/{botId}/users/{userId}/unblock)
+func (_ Unimplemented) UnblockBot(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook deliveries of a bot
+// (GET /admin/bots/{botId}/webhook/deliveries)
+func (_ Unimplemented) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, botId string, params ListWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a webhook delivery
+// (GET /admin/bots/{botId}/webhook/deliveries/{deliveryId})
+func (_ Unimplemented) GetWebhookDelivery(w http.ResponseWriter, r *http.Request, botId string, deliveryId int32) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the webhook redelivery setting of a bot
+// (PUT /admin/bots/{botId}/webhook/redelivery)
+func (_ Unimplemented) SetWebhookRedelivery(w http.ResponseWriter, r *http.Request, botId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a Messaging API call by request ID
+// (GET /admin/requests/{requestId})
+func (_ Unimplemented) GetApiCall(w http.ResponseWriter, r *http.Request, requestId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the demographic attributes of a user
+// (PUT /admin/users/{userId}/attributes)
+func (_ Unimplemented) SetUserAttributes(w http.ResponseWriter, r *http.Request, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the phone number of a user
+// (PUT /admin/users/{userId}/phone-number)
+func (_ Unimplemented) SetUserPhoneNumber(w http.ResponseWriter, r *http.Request, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -213,8 +771,8 @@ func (siw *ServerInterfaceWrapper) CreateBot(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateFollowers operation middleware
-func (siw *ServerInterfaceWrapper) CreateFollowers(w http.ResponseWriter, r *http.Request) {
+// JoinChat operation middleware
+func (siw *ServerInterfaceWrapper) JoinChat(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -228,7 +786,7 @@ func (siw *ServerInterfaceWrapper) CreateFollowers(w http.ResponseWriter, r *htt
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateFollowers(w, r, botId)
+		siw.Handler.JoinChat(w, r, botId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -238,262 +796,1795 @@ func (siw *ServerInterfaceWrapper) CreateFollowers(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
-
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+// GetLoadingAnimation operation middleware
+func (siw *ServerInterfaceWrapper) GetLoadingAnimation(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	var err error
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Path parameter "botId" -------------
+	var botId string
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "chatId" -------------
+	var chatId string
 
-type RequiredParamError struct {
-	ParamName string
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "chatId", chi.URLParam(r, "chatId"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chatId", Err: err})
+		return
+	}
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLoadingAnimation(w, r, botId, chatId)
+	}))
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// GetConversation operation middleware
+func (siw *ServerInterfaceWrapper) GetConversation(w http.ResponseWriter, r *http.Request) {
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "chatId" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chatId", chi.URLParam(r, "chatId"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chatId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConversation(w, r, botId, chatId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateFollowers operation middleware
+func (siw *ServerInterfaceWrapper) CreateFollowers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateFollowers(w, r, botId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetMessageQuotaPlan operation middleware
+func (siw *ServerInterfaceWrapper) SetMessageQuotaPlan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetMessageQuotaPlan(w, r, botId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BlockBot operation middleware
+func (siw *ServerInterfaceWrapper) BlockBot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BlockBot(w, r, botId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// FollowBot operation middleware
+func (siw *ServerInterfaceWrapper) FollowBot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FollowBot(w, r, botId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SendUserMessage operation middleware
+func (siw *ServerInterfaceWrapper) SendUserMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendUserMessage(w, r, botId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnblockBot operation middleware
+func (siw *ServerInterfaceWrapper) UnblockBot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnblockBot(w, r, botId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "webhookEventId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "webhookEventId", r.URL.Query(), &params.WebhookEventId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookEventId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, botId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "deliveryId" -------------
+	var deliveryId int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deliveryId", chi.URLParam(r, "deliveryId"), &deliveryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deliveryId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookDelivery(w, r, botId, deliveryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetWebhookRedelivery operation middleware
+func (siw *ServerInterfaceWrapper) SetWebhookRedelivery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "botId" -------------
+	var botId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "botId", chi.URLParam(r, "botId"), &botId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "botId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetWebhookRedelivery(w, r, botId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiCall operation middleware
+func (siw *ServerInterfaceWrapper) GetApiCall(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "requestId" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiCall(w, r, requestId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserAttributes operation middleware
+func (siw *ServerInterfaceWrapper) SetUserAttributes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserAttributes(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserPhoneNumber operation middleware
+func (siw *ServerInterfaceWrapper) SetUserPhoneNumber(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserPhoneNumber(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
 
 func (e *InvalidParamFormatError) Unwrap() error {
 	return e.Err
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots", wrapper.CreateBot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots/{botId}/chats", wrapper.JoinChat)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/bots/{botId}/chats/{chatId}/loading", wrapper.GetLoadingAnimation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/bots/{botId}/chats/{chatId}/messages", wrapper.GetConversation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots/{botId}/followers", wrapper.CreateFollowers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/bots/{botId}/message-quota/plan", wrapper.SetMessageQuotaPlan)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots/{botId}/users/{userId}/block", wrapper.BlockBot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots/{botId}/users/{userId}/follow", wrapper.FollowBot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots/{botId}/users/{userId}/messages", wrapper.SendUserMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/bots/{botId}/users/{userId}/unblock", wrapper.UnblockBot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/bots/{botId}/webhook/deliveries", wrapper.ListWebhookDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/bots/{botId}/webhook/deliveries/{deliveryId}", wrapper.GetWebhookDelivery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/bots/{botId}/webhook/redelivery", wrapper.SetWebhookRedelivery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/requests/{requestId}", wrapper.GetApiCall)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{userId}/attributes", wrapper.SetUserAttributes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{userId}/phone-number", wrapper.SetUserPhoneNumber)
+	})
+
+	return r
+}
+
+type CreateBotRequestObject struct {
+	Body *CreateBotJSONRequestBody
+}
+
+type CreateBotResponseObject interface {
+	VisitCreateBotResponse(w http.ResponseWriter) error
+}
+
+type CreateBot201JSONResponse BotInfoResponse
+
+func (response CreateBot201JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBot400JSONResponse ErrorResponse
+
+func (response CreateBot400JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBot409JSONResponse ErrorResponse
+
+func (response CreateBot409JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBot500JSONResponse ErrorResponse
+
+func (response CreateBot500JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChatRequestObject struct {
+	BotId string `json:"botId"`
+	Body  *JoinChatJSONRequestBody
+}
+
+type JoinChatResponseObject interface {
+	VisitJoinChatResponse(w http.ResponseWriter) error
+}
+
+type JoinChat202JSONResponse JoinChatResponse
+
+func (response JoinChat202JSONResponse) VisitJoinChatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChat400JSONResponse ErrorResponse
+
+func (response JoinChat400JSONResponse) VisitJoinChatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChat404JSONResponse ErrorResponse
+
+func (response JoinChat404JSONResponse) VisitJoinChatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChat500JSONResponse ErrorResponse
+
+func (response JoinChat500JSONResponse) VisitJoinChatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoadingAnimationRequestObject struct {
+	BotId  string `json:"botId"`
+	ChatId string `json:"chatId"`
+}
+
+type GetLoadingAnimationResponseObject interface {
+	VisitGetLoadingAnimationResponse(w http.ResponseWriter) error
+}
+
+type GetLoadingAnimation200JSONResponse LoadingAnimationResponse
+
+func (response GetLoadingAnimation200JSONResponse) VisitGetLoadingAnimationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoadingAnimation404JSONResponse ErrorResponse
+
+func (response GetLoadingAnimation404JSONResponse) VisitGetLoadingAnimationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoadingAnimation500JSONResponse ErrorResponse
+
+func (response GetLoadingAnimation500JSONResponse) VisitGetLoadingAnimationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConversationRequestObject struct {
+	BotId  string `json:"botId"`
+	ChatId string `json:"chatId"`
+}
+
+type GetConversationResponseObject interface {
+	VisitGetConversationResponse(w http.ResponseWriter) error
+}
+
+type GetConversation200JSONResponse ConversationResponse
+
+func (response GetConversation200JSONResponse) VisitGetConversationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConversation404JSONResponse ErrorResponse
+
+func (response GetConversation404JSONResponse) VisitGetConversationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConversation500JSONResponse ErrorResponse
+
+func (response GetConversation500JSONResponse) VisitGetConversationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFollowersRequestObject struct {
+	BotId string `json:"botId"`
+	Body  *CreateFollowersJSONRequestBody
+}
+
+type CreateFollowersResponseObject interface {
+	VisitCreateFollowersResponse(w http.ResponseWriter) error
+}
+
+type CreateFollowers201JSONResponse CreateFollowersResponse
+
+func (response CreateFollowers201JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFollowers400JSONResponse ErrorResponse
+
+func (response CreateFollowers400JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFollowers404JSONResponse ErrorResponse
+
+func (response CreateFollowers404JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFollowers500JSONResponse ErrorResponse
+
+func (response CreateFollowers500JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageQuotaPlanRequestObject struct {
+	BotId string `json:"botId"`
+	Body  *SetMessageQuotaPlanJSONRequestBody
+}
+
+type SetMessageQuotaPlanResponseObject interface {
+	VisitSetMessageQuotaPlanResponse(w http.ResponseWriter) error
+}
+
+type SetMessageQuotaPlan200JSONResponse BotInfoResponse
+
+func (response SetMessageQuotaPlan200JSONResponse) VisitSetMessageQuotaPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageQuotaPlan400JSONResponse ErrorResponse
+
+func (response SetMessageQuotaPlan400JSONResponse) VisitSetMessageQuotaPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageQuotaPlan404JSONResponse ErrorResponse
+
+func (response SetMessageQuotaPlan404JSONResponse) VisitSetMessageQuotaPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageQuotaPlan500JSONResponse ErrorResponse
+
+func (response SetMessageQuotaPlan500JSONResponse) VisitSetMessageQuotaPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BlockBotRequestObject struct {
+	BotId  string `json:"botId"`
+	UserId string `json:"userId"`
+}
+
+type BlockBotResponseObject interface {
+	VisitBlockBotResponse(w http.ResponseWriter) error
+}
+
+type BlockBot202JSONResponse FollowStateResponse
+
+func (response BlockBot202JSONResponse) VisitBlockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BlockBot404JSONResponse ErrorResponse
+
+func (response BlockBot404JSONResponse) VisitBlockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BlockBot409JSONResponse ErrorResponse
+
+func (response BlockBot409JSONResponse) VisitBlockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BlockBot500JSONResponse ErrorResponse
+
+func (response BlockBot500JSONResponse) VisitBlockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FollowBotRequestObject struct {
+	BotId  string `json:"botId"`
+	UserId string `json:"userId"`
+}
+
+type FollowBotResponseObject interface {
+	VisitFollowBotResponse(w http.ResponseWriter) error
+}
+
+type FollowBot202JSONResponse FollowStateResponse
+
+func (response FollowBot202JSONResponse) VisitFollowBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FollowBot404JSONResponse ErrorResponse
+
+func (response FollowBot404JSONResponse) VisitFollowBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FollowBot409JSONResponse ErrorResponse
+
+func (response FollowBot409JSONResponse) VisitFollowBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FollowBot500JSONResponse ErrorResponse
+
+func (response FollowBot500JSONResponse) VisitFollowBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendUserMessageRequestObject struct {
+	BotId  string `json:"botId"`
+	UserId string `json:"userId"`
+	Body   *SendUserMessageJSONRequestBody
+}
+
+type SendUserMessageResponseObject interface {
+	VisitSendUserMessageResponse(w http.ResponseWriter) error
+}
+
+type SendUserMessage202JSONResponse SendUserMessageResponse
+
+func (response SendUserMessage202JSONResponse) VisitSendUserMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendUserMessage400JSONResponse ErrorResponse
+
+func (response SendUserMessage400JSONResponse) VisitSendUserMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendUserMessage404JSONResponse ErrorResponse
+
+func (response SendUserMessage404JSONResponse) VisitSendUserMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendUserMessage500JSONResponse ErrorResponse
+
+func (response SendUserMessage500JSONResponse) VisitSendUserMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnblockBotRequestObject struct {
+	BotId  string `json:"botId"`
+	UserId string `json:"userId"`
+}
+
+type UnblockBotResponseObject interface {
+	VisitUnblockBotResponse(w http.ResponseWriter) error
+}
+
+type UnblockBot202JSONResponse FollowStateResponse
+
+func (response UnblockBot202JSONResponse) VisitUnblockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnblockBot404JSONResponse ErrorResponse
+
+func (response UnblockBot404JSONResponse) VisitUnblockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnblockBot409JSONResponse ErrorResponse
+
+func (response UnblockBot409JSONResponse) VisitUnblockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnblockBot500JSONResponse ErrorResponse
+
+func (response UnblockBot500JSONResponse) VisitUnblockBotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveriesRequestObject struct {
+	BotId  string `json:"botId"`
+	Params ListWebhookDeliveriesParams
+}
+
+type ListWebhookDeliveriesResponseObject interface {
+	VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookDeliveries200JSONResponse WebhookDeliveryListResponse
+
+func (response ListWebhookDeliveries200JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries400JSONResponse ErrorResponse
+
+func (response ListWebhookDeliveries400JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries404JSONResponse ErrorResponse
+
+func (response ListWebhookDeliveries404JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries500JSONResponse ErrorResponse
+
+func (response ListWebhookDeliveries500JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDeliveryRequestObject struct {
+	BotId      string `json:"botId"`
+	DeliveryId int32  `json:"deliveryId"`
+}
+
+type GetWebhookDeliveryResponseObject interface {
+	VisitGetWebhookDeliveryResponse(w http.ResponseWriter) error
+}
+
+type GetWebhookDelivery200JSONResponse WebhookDelivery
+
+func (response GetWebhookDelivery200JSONResponse) VisitGetWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDelivery404JSONResponse ErrorResponse
+
+func (response GetWebhookDelivery404JSONResponse) VisitGetWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookDelivery500JSONResponse ErrorResponse
+
+func (response GetWebhookDelivery500JSONResponse) VisitGetWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWebhookRedeliveryRequestObject struct {
+	BotId string `json:"botId"`
+	Body  *SetWebhookRedeliveryJSONRequestBody
+}
+
+type SetWebhookRedeliveryResponseObject interface {
+	VisitSetWebhookRedeliveryResponse(w http.ResponseWriter) error
+}
+
+type SetWebhookRedelivery200JSONResponse BotInfoResponse
+
+func (response SetWebhookRedelivery200JSONResponse) VisitSetWebhookRedeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWebhookRedelivery400JSONResponse ErrorResponse
+
+func (response SetWebhookRedelivery400JSONResponse) VisitSetWebhookRedeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWebhookRedelivery404JSONResponse ErrorResponse
+
+func (response SetWebhookRedelivery404JSONResponse) VisitSetWebhookRedeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWebhookRedelivery500JSONResponse ErrorResponse
+
+func (response SetWebhookRedelivery500JSONResponse) VisitSetWebhookRedeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiCallRequestObject struct {
+	RequestId string `json:"requestId"`
+}
+
+type GetApiCallResponseObject interface {
+	VisitGetApiCallResponse(w http.ResponseWriter) error
+}
+
+type GetApiCall200JSONResponse ApiCall
+
+func (response GetApiCall200JSONResponse) VisitGetApiCallResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiCall404JSONResponse ErrorResponse
+
+func (response GetApiCall404JSONResponse) VisitGetApiCallResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiCall500JSONResponse ErrorResponse
+
+func (response GetApiCall500JSONResponse) VisitGetApiCallResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserAttributesRequestObject struct {
+	UserId string `json:"userId"`
+	Body   *SetUserAttributesJSONRequestBody
+}
+
+type SetUserAttributesResponseObject interface {
+	VisitSetUserAttributesResponse(w http.ResponseWriter) error
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+type SetUserAttributes200JSONResponse UserAttributesResponse
+
+func (response SetUserAttributes200JSONResponse) VisitSetUserAttributesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+type SetUserAttributes400JSONResponse ErrorResponse
+
+func (response SetUserAttributes400JSONResponse) VisitSetUserAttributesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+type SetUserAttributes404JSONResponse ErrorResponse
+
+func (response SetUserAttributes404JSONResponse) VisitSetUserAttributesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+type SetUserAttributes500JSONResponse ErrorResponse
+
+func (response SetUserAttributes500JSONResponse) VisitSetUserAttributesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPhoneNumberRequestObject struct {
+	UserId string `json:"userId"`
+	Body   *SetUserPhoneNumberJSONRequestBody
+}
+
+type SetUserPhoneNumberResponseObject interface {
+	VisitSetUserPhoneNumberResponse(w http.ResponseWriter) error
+}
+
+type SetUserPhoneNumber200JSONResponse PhoneNumberResponse
+
+func (response SetUserPhoneNumber200JSONResponse) VisitSetUserPhoneNumberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPhoneNumber400JSONResponse ErrorResponse
+
+func (response SetUserPhoneNumber400JSONResponse) VisitSetUserPhoneNumberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPhoneNumber404JSONResponse ErrorResponse
+
+func (response SetUserPhoneNumber404JSONResponse) VisitSetUserPhoneNumberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPhoneNumber409JSONResponse ErrorResponse
+
+func (response SetUserPhoneNumber409JSONResponse) VisitSetUserPhoneNumberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserPhoneNumber500JSONResponse ErrorResponse
+
+func (response SetUserPhoneNumber500JSONResponse) VisitSetUserPhoneNumberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Create a new bot
+	// (POST /admin/bots)
+	CreateBot(ctx context.Context, request CreateBotRequestObject) (CreateBotResponseObject, error)
+	// Make a bot join a group chat or multi-person chat
+	// (POST /admin/bots/{botId}/chats)
+	JoinChat(ctx context.Context, request JoinChatRequestObject) (JoinChatResponseObject, error)
+	// Get the loading animation in a chat
+	// (GET /admin/bots/{botId}/chats/{chatId}/loading)
+	GetLoadingAnimation(ctx context.Context, request GetLoadingAnimationRequestObject) (GetLoadingAnimationResponseObject, error)
+	// Get the conversation between a bot and a chat
+	// (GET /admin/bots/{botId}/chats/{chatId}/messages)
+	GetConversation(ctx context.Context, request GetConversationRequestObject) (GetConversationResponseObject, error)
+	// Create dummy followers for a bot
+	// (POST /admin/bots/{botId}/followers)
+	CreateFollowers(ctx context.Context, request CreateFollowersRequestObject) (CreateFollowersResponseObject, error)
+	// Set the message quota plan of a bot
+	// (PUT /admin/bots/{botId}/message-quota/plan)
+	SetMessageQuotaPlan(ctx context.Context, request SetMessageQuotaPlanRequestObject) (SetMessageQuotaPlanResponseObject, error)
+	// Make a user block a bot
+	// (POST /admin/bots/{botId}/users/{userId}/block)
+	BlockBot(ctx context.Context, request BlockBotRequestObject) (BlockBotResponseObject, error)
+	// Make a user follow a bot
+	// (POST /admin/bots/{botId}/users/{userId}/follow)
+	FollowBot(ctx context.Context, request FollowBotRequestObject) (FollowBotResponseObject, error)
+	// Send a message from a user to a bot
+	// (POST /admin/bots/{botId}/users/{userId}/messages)
+	SendUserMessage(ctx context.Context, request SendUserMessageRequestObject) (SendUserMessageResponseObject, error)
+	// Make a user unblock a bot
+	// (POST /admin/bots/{botId}/users/{userId}/unblock)
+	UnblockBot(ctx context.Context, request UnblockBotRequestObject) (UnblockBotResponseObject, error)
+	// List webhook deliveries of a bot
+	// (GET /admin/bots/{botId}/webhook/deliveries)
+	ListWebhookDeliveries(ctx context.Context, request ListWebhookDeliveriesRequestObject) (ListWebhookDeliveriesResponseObject, error)
+	// Get a webhook delivery
+	// (GET /admin/bots/{botId}/webhook/deliveries/{deliveryId})
+	GetWebhookDelivery(ctx context.Context, request GetWebhookDeliveryRequestObject) (GetWebhookDeliveryResponseObject, error)
+	// Set the webhook redelivery setting of a bot
+	// (PUT /admin/bots/{botId}/webhook/redelivery)
+	SetWebhookRedelivery(ctx context.Context, request SetWebhookRedeliveryRequestObject) (SetWebhookRedeliveryResponseObject, error)
+	// Get a Messaging API call by request ID
+	// (GET /admin/requests/{requestId})
+	GetApiCall(ctx context.Context, request GetApiCallRequestObject) (GetApiCallResponseObject, error)
+	// Set the demographic attributes of a user
+	// (PUT /admin/users/{userId}/attributes)
+	SetUserAttributes(ctx context.Context, request SetUserAttributesRequestObject) (SetUserAttributesResponseObject, error)
+	// Set the phone number of a user
+	// (PUT /admin/users/{userId}/phone-number)
+	SetUserPhoneNumber(ctx context.Context, request SetUserPhoneNumberRequestObject) (SetUserPhoneNumberResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// CreateBot operation middleware
+func (sh *strictHandler) CreateBot(w http.ResponseWriter, r *http.Request) {
+	var request CreateBotRequestObject
+
+	var body CreateBotJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateBot(ctx, request.(CreateBotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateBot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateBotResponseObject); ok {
+		if err := validResponse.VisitCreateBotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// JoinChat operation middleware
+func (sh *strictHandler) JoinChat(w http.ResponseWriter, r *http.Request, botId string) {
+	var request JoinChatRequestObject
+
+	request.BotId = botId
+
+	var body JoinChatJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.JoinChat(ctx, request.(JoinChatRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "JoinChat")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(JoinChatResponseObject); ok {
+		if err := validResponse.VisitJoinChatResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLoadingAnimation operation middleware
+func (sh *strictHandler) GetLoadingAnimation(w http.ResponseWriter, r *http.Request, botId string, chatId string) {
+	var request GetLoadingAnimationRequestObject
+
+	request.BotId = botId
+	request.ChatId = chatId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLoadingAnimation(ctx, request.(GetLoadingAnimationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLoadingAnimation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLoadingAnimationResponseObject); ok {
+		if err := validResponse.VisitGetLoadingAnimationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetConversation operation middleware
+func (sh *strictHandler) GetConversation(w http.ResponseWriter, r *http.Request, botId string, chatId string) {
+	var request GetConversationRequestObject
+
+	request.BotId = botId
+	request.ChatId = chatId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConversation(ctx, request.(GetConversationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConversation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetConversationResponseObject); ok {
+		if err := validResponse.VisitGetConversationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFollowers operation middleware
+func (sh *strictHandler) CreateFollowers(w http.ResponseWriter, r *http.Request, botId string) {
+	var request CreateFollowersRequestObject
+
+	request.BotId = botId
+
+	var body CreateFollowersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFollowers(ctx, request.(CreateFollowersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFollowers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateFollowersResponseObject); ok {
+		if err := validResponse.VisitCreateFollowersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+// SetMessageQuotaPlan operation middleware
+func (sh *strictHandler) SetMessageQuotaPlan(w http.ResponseWriter, r *http.Request, botId string) {
+	var request SetMessageQuotaPlanRequestObject
+
+	request.BotId = botId
+
+	var body SetMessageQuotaPlanJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetMessageQuotaPlan(ctx, request.(SetMessageQuotaPlanRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetMessageQuotaPlan")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetMessageQuotaPlanResponseObject); ok {
+		if err := validResponse.VisitSetMessageQuotaPlanResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// BlockBot operation middleware
+func (sh *strictHandler) BlockBot(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	var request BlockBotRequestObject
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	request.BotId = botId
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BlockBot(ctx, request.(BlockBotRequestObject))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BlockBot")
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/admin/bots", wrapper.CreateBot)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/admin/bots/{botId}/followers", wrapper.CreateFollowers)
-	})
+	response, err := handler(r.Context(), w, r, request)
 
-	return r
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BlockBotResponseObject); ok {
+		if err := validResponse.VisitBlockBotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateBotRequestObject struct {
-	Body *CreateBotJSONRequestBody
-}
+// FollowBot operation middleware
+func (sh *strictHandler) FollowBot(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	var request FollowBotRequestObject
 
-type CreateBotResponseObject interface {
-	VisitCreateBotResponse(w http.ResponseWriter) error
-}
+	request.BotId = botId
+	request.UserId = userId
 
-type CreateBot201JSONResponse BotInfoResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.FollowBot(ctx, request.(FollowBotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FollowBot")
+	}
 
-func (response CreateBot201JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(FollowBotResponseObject); ok {
+		if err := validResponse.VisitFollowBotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateBot400JSONResponse ErrorResponse
+// SendUserMessage operation middleware
+func (sh *strictHandler) SendUserMessage(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	var request SendUserMessageRequestObject
 
-func (response CreateBot400JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.BotId = botId
+	request.UserId = userId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SendUserMessageJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type CreateBot409JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SendUserMessage(ctx, request.(SendUserMessageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SendUserMessage")
+	}
 
-func (response CreateBot409JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SendUserMessageResponseObject); ok {
+		if err := validResponse.VisitSendUserMessageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateBot500JSONResponse ErrorResponse
+// UnblockBot operation middleware
+func (sh *strictHandler) UnblockBot(w http.ResponseWriter, r *http.Request, botId string, userId string) {
+	var request UnblockBotRequestObject
 
-func (response CreateBot500JSONResponse) VisitCreateBotResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.BotId = botId
+	request.UserId = userId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnblockBot(ctx, request.(UnblockBotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnblockBot")
+	}
 
-type CreateFollowersRequestObject struct {
-	BotId string `json:"botId"`
-	Body  *CreateFollowersJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateFollowersResponseObject interface {
-	VisitCreateFollowersResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnblockBotResponseObject); ok {
+		if err := validResponse.VisitUnblockBotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateFollowers201JSONResponse CreateFollowersResponse
+// ListWebhookDeliveries operation middleware
+func (sh *strictHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, botId string, params ListWebhookDeliveriesParams) {
+	var request ListWebhookDeliveriesRequestObject
 
-func (response CreateFollowers201JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	request.BotId = botId
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookDeliveries(ctx, request.(ListWebhookDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookDeliveries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeliveriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateFollowers400JSONResponse ErrorResponse
+// GetWebhookDelivery operation middleware
+func (sh *strictHandler) GetWebhookDelivery(w http.ResponseWriter, r *http.Request, botId string, deliveryId int32) {
+	var request GetWebhookDeliveryRequestObject
 
-func (response CreateFollowers400JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.BotId = botId
+	request.DeliveryId = deliveryId
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhookDelivery(ctx, request.(GetWebhookDeliveryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhookDelivery")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWebhookDeliveryResponseObject); ok {
+		if err := validResponse.VisitGetWebhookDeliveryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateFollowers404JSONResponse ErrorResponse
+// SetWebhookRedelivery operation middleware
+func (sh *strictHandler) SetWebhookRedelivery(w http.ResponseWriter, r *http.Request, botId string) {
+	var request SetWebhookRedeliveryRequestObject
 
-func (response CreateFollowers404JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.BotId = botId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SetWebhookRedeliveryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type CreateFollowers500JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetWebhookRedelivery(ctx, request.(SetWebhookRedeliveryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetWebhookRedelivery")
+	}
 
-func (response CreateFollowers500JSONResponse) VisitCreateFollowersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetWebhookRedeliveryResponseObject); ok {
+		if err := validResponse.VisitSetWebhookRedeliveryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Create a new bot
-	// (POST /admin/bots)
-	CreateBot(ctx context.Context, request CreateBotRequestObject) (CreateBotResponseObject, error)
-	// Create dummy followers for a bot
-	// (POST /admin/bots/{botId}/followers)
-	CreateFollowers(ctx context.Context, request CreateFollowersRequestObject) (CreateFollowersResponseObject, error)
-}
+// GetApiCall operation middleware
+func (sh *strictHandler) GetApiCall(w http.ResponseWriter, r *http.Request, requestId string) {
+	var request GetApiCallRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	request.RequestId = requestId
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiCall(ctx, request.(GetApiCallRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiCall")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiCallResponseObject); ok {
+		if err := validResponse.VisitGetApiCallResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// SetUserAttributes operation middleware
+func (sh *strictHandler) SetUserAttributes(w http.ResponseWriter, r *http.Request, userId string) {
+	var request SetUserAttributesRequestObject
 
-// CreateBot operation middleware
-func (sh *strictHandler) CreateBot(w http.ResponseWriter, r *http.Request) {
-	var request CreateBotRequestObject
+	request.UserId = userId
 
-	var body CreateBotJSONRequestBody
+	var body SetUserAttributesJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -501,18 +2592,18 @@ func (sh *strictHandler) CreateBot(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateBot(ctx, request.(CreateBotRequestObject))
+		return sh.ssi.SetUserAttributes(ctx, request.(SetUserAttributesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateBot")
+		handler = middleware(handler, "SetUserAttributes")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateBotResponseObject); ok {
-		if err := validResponse.VisitCreateBotResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetUserAttributesResponseObject); ok {
+		if err := validResponse.VisitSetUserAttributesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -520,13 +2611,13 @@ func (sh *strictHandler) CreateBot(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateFollowers operation middleware
-func (sh *strictHandler) CreateFollowers(w http.ResponseWriter, r *http.Request, botId string) {
-	var request CreateFollowersRequestObject
+// SetUserPhoneNumber operation middleware
+func (sh *strictHandler) SetUserPhoneNumber(w http.ResponseWriter, r *http.Request, userId string) {
+	var request SetUserPhoneNumberRequestObject
 
-	request.BotId = botId
+	request.UserId = userId
 
-	var body CreateFollowersJSONRequestBody
+	var body SetUserPhoneNumberJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -534,18 +2625,18 @@ func (sh *strictHandler) CreateFollowers(w http.ResponseWriter, r *http.Request,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateFollowers(ctx, request.(CreateFollowersRequestObject))
+		return sh.ssi.SetUserPhoneNumber(ctx, request.(SetUserPhoneNumberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateFollowers")
+		handler = middleware(handler, "SetUserPhoneNumber")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateFollowersResponseObject); ok {
-		if err := validResponse.VisitCreateFollowersResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetUserPhoneNumberResponseObject); ok {
+		if err := validResponse.VisitSetUserPhoneNumberResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
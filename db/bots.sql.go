@@ -17,7 +17,9 @@ INSERT INTO bots (
     display_name,
     mark_as_read_mode,
     picture_url,
-    premium_id
+    premium_id,
+    channel_secret,
+    webhook_redelivery
 ) VALUES (
           $1,
             $2,
@@ -25,18 +27,22 @@ INSERT INTO bots (
             $4,
           $5,
             $6,
-            $7
-) RETURNING id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, created_at, updated_at
+            $7,
+            $8,
+            $9
+) RETURNING id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at
 `
 
 type CreateBotParams struct {
-	UserID         string  `db:"user_id" json:"user_id"`
-	BasicID        string  `db:"basic_id" json:"basic_id"`
-	ChatMode       string  `db:"chat_mode" json:"chat_mode"`
-	DisplayName    string  `db:"display_name" json:"display_name"`
-	MarkAsReadMode string  `db:"mark_as_read_mode" json:"mark_as_read_mode"`
-	PictureUrl     *string `db:"picture_url" json:"picture_url"`
-	PremiumID      *string `db:"premium_id" json:"premium_id"`
+	UserID            string  `db:"user_id" json:"user_id"`
+	BasicID           string  `db:"basic_id" json:"basic_id"`
+	ChatMode          string  `db:"chat_mode" json:"chat_mode"`
+	DisplayName       string  `db:"display_name" json:"display_name"`
+	MarkAsReadMode    string  `db:"mark_as_read_mode" json:"mark_as_read_mode"`
+	PictureUrl        *string `db:"picture_url" json:"picture_url"`
+	PremiumID         *string `db:"premium_id" json:"premium_id"`
+	ChannelSecret     string  `db:"channel_secret" json:"channel_secret"`
+	WebhookRedelivery bool    `db:"webhook_redelivery" json:"webhook_redelivery"`
 }
 
 func (q *Queries) CreateBot(ctx context.Context, arg CreateBotParams) (Bot, error) {
@@ -48,6 +54,8 @@ func (q *Queries) CreateBot(ctx context.Context, arg CreateBotParams) (Bot, erro
 		arg.MarkAsReadMode,
 		arg.PictureUrl,
 		arg.PremiumID,
+		arg.ChannelSecret,
+		arg.WebhookRedelivery,
 	)
 	var i Bot
 	err := row.Scan(
@@ -59,6 +67,9 @@ func (q *Queries) CreateBot(ctx context.Context, arg CreateBotParams) (Bot, erro
 		&i.MarkAsReadMode,
 		&i.PictureUrl,
 		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -76,7 +87,7 @@ func (q *Queries) DeleteBot(ctx context.Context, userID string) error {
 }
 
 const getBot = `-- name: GetBot :one
-SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, created_at, updated_at FROM bots
+SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at FROM bots
 WHERE id = $1
 `
 
@@ -92,6 +103,9 @@ func (q *Queries) GetBot(ctx context.Context, id int32) (Bot, error) {
 		&i.MarkAsReadMode,
 		&i.PictureUrl,
 		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -99,7 +113,7 @@ func (q *Queries) GetBot(ctx context.Context, id int32) (Bot, error) {
 }
 
 const getBotByBasicID = `-- name: GetBotByBasicID :one
-SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, created_at, updated_at FROM bots
+SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at FROM bots
 WHERE basic_id = $1
 `
 
@@ -115,6 +129,9 @@ func (q *Queries) GetBotByBasicID(ctx context.Context, basicID string) (Bot, err
 		&i.MarkAsReadMode,
 		&i.PictureUrl,
 		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -122,7 +139,7 @@ func (q *Queries) GetBotByBasicID(ctx context.Context, basicID string) (Bot, err
 }
 
 const getBotByUserID = `-- name: GetBotByUserID :one
-SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, created_at, updated_at FROM bots
+SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at FROM bots
 WHERE user_id = $1
 `
 
@@ -138,6 +155,9 @@ func (q *Queries) GetBotByUserID(ctx context.Context, userID string) (Bot, error
 		&i.MarkAsReadMode,
 		&i.PictureUrl,
 		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -145,7 +165,7 @@ func (q *Queries) GetBotByUserID(ctx context.Context, userID string) (Bot, error
 }
 
 const listBots = `-- name: ListBots :many
-SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, created_at, updated_at FROM bots
+SELECT id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at FROM bots
 ORDER BY created_at DESC
 `
 
@@ -167,6 +187,9 @@ func (q *Queries) ListBots(ctx context.Context) ([]Bot, error) {
 			&i.MarkAsReadMode,
 			&i.PictureUrl,
 			&i.PremiumID,
+			&i.ChannelSecret,
+			&i.WebhookRedelivery,
+			&i.MessageQuotaPlan,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -191,7 +214,7 @@ SET
     premium_id = $6,
     updated_at = CURRENT_TIMESTAMP
 WHERE user_id = $7
-RETURNING id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, created_at, updated_at
+RETURNING id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at
 `
 
 type UpdateBotParams struct {
@@ -224,6 +247,79 @@ func (q *Queries) UpdateBot(ctx context.Context, arg UpdateBotParams) (Bot, erro
 		&i.MarkAsReadMode,
 		&i.PictureUrl,
 		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateBotWebhookRedelivery = `-- name: UpdateBotWebhookRedelivery :one
+UPDATE bots
+SET
+    webhook_redelivery = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $2
+RETURNING id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at
+`
+
+type UpdateBotWebhookRedeliveryParams struct {
+	WebhookRedelivery bool   `db:"webhook_redelivery" json:"webhook_redelivery"`
+	UserID            string `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) UpdateBotWebhookRedelivery(ctx context.Context, arg UpdateBotWebhookRedeliveryParams) (Bot, error) {
+	row := q.db.QueryRow(ctx, updateBotWebhookRedelivery, arg.WebhookRedelivery, arg.UserID)
+	var i Bot
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BasicID,
+		&i.ChatMode,
+		&i.DisplayName,
+		&i.MarkAsReadMode,
+		&i.PictureUrl,
+		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateBotMessageQuotaPlan = `-- name: UpdateBotMessageQuotaPlan :one
+UPDATE bots
+SET
+    message_quota_plan = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $2
+RETURNING id, user_id, basic_id, chat_mode, display_name, mark_as_read_mode, picture_url, premium_id, channel_secret, webhook_redelivery, message_quota_plan, created_at, updated_at
+`
+
+type UpdateBotMessageQuotaPlanParams struct {
+	MessageQuotaPlan string `db:"message_quota_plan" json:"message_quota_plan"`
+	UserID           string `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) UpdateBotMessageQuotaPlan(ctx context.Context, arg UpdateBotMessageQuotaPlanParams) (Bot, error) {
+	row := q.db.QueryRow(ctx, updateBotMessageQuotaPlan, arg.MessageQuotaPlan, arg.UserID)
+	var i Bot
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.BasicID,
+		&i.ChatMode,
+		&i.DisplayName,
+		&i.MarkAsReadMode,
+		&i.PictureUrl,
+		&i.PremiumID,
+		&i.ChannelSecret,
+		&i.WebhookRedelivery,
+		&i.MessageQuotaPlan,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -42,6 +42,114 @@ func (q *Queries) CreateBotFollowers(ctx context.Context, arg []CreateBotFollowe
 	return q.db.CopyFrom(ctx, []string{"bot_followers"}, []string{"bot_id", "user_id"}, &iteratorForCreateBotFollowers{rows: arg})
 }
 
+// iteratorForCreateConversationMessages implements pgx.CopyFromSource.
+type iteratorForCreateConversationMessages struct {
+	rows                 []CreateConversationMessagesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateConversationMessages) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateConversationMessages) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].BotID,
+		r.rows[0].ChatType,
+		r.rows[0].ChatID,
+		r.rows[0].Sender,
+		r.rows[0].MessageID,
+		r.rows[0].Content,
+		r.rows[0].ReadAt,
+	}, nil
+}
+
+func (r iteratorForCreateConversationMessages) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateConversationMessages(ctx context.Context, arg []CreateConversationMessagesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"conversation_messages"}, []string{"bot_id", "chat_type", "chat_id", "sender", "message_id", "content", "read_at"}, &iteratorForCreateConversationMessages{rows: arg})
+}
+
+// iteratorForCreateMessageDeliveries implements pgx.CopyFromSource.
+type iteratorForCreateMessageDeliveries struct {
+	rows                 []CreateMessageDeliveriesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateMessageDeliveries) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateMessageDeliveries) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].MessageID,
+		r.rows[0].RecipientType,
+		r.rows[0].RecipientID,
+	}, nil
+}
+
+func (r iteratorForCreateMessageDeliveries) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateMessageDeliveries(ctx context.Context, arg []CreateMessageDeliveriesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"message_deliveries"}, []string{"message_id", "recipient_type", "recipient_id"}, &iteratorForCreateMessageDeliveries{rows: arg})
+}
+
+// iteratorForCreateSentMessages implements pgx.CopyFromSource.
+type iteratorForCreateSentMessages struct {
+	rows                 []CreateSentMessagesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateSentMessages) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateSentMessages) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].MessageID,
+		r.rows[0].BotID,
+		r.rows[0].Position,
+		r.rows[0].LineMessageID,
+		r.rows[0].QuoteToken,
+	}, nil
+}
+
+func (r iteratorForCreateSentMessages) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateSentMessages(ctx context.Context, arg []CreateSentMessagesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"sent_messages"}, []string{"message_id", "bot_id", "position", "line_message_id", "quote_token"}, &iteratorForCreateSentMessages{rows: arg})
+}
+
 // iteratorForCreateUsers implements pgx.CopyFromSource.
 type iteratorForCreateUsers struct {
 	rows                 []CreateUsersParams
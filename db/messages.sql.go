@@ -30,19 +30,22 @@ INSERT INTO messages (
     recipient_id,
     content,
     retry_key,
+    request_id,
     created_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP
-) RETURNING id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, request_id, created_at
 `
 
 type CreateMessageParams struct {
-	BotID         int32       `db:"bot_id" json:"bot_id"`
-	MessageType   string      `db:"message_type" json:"message_type"`
-	RecipientType *string     `db:"recipient_type" json:"recipient_type"`
-	RecipientID   *string     `db:"recipient_id" json:"recipient_id"`
-	Content       []byte      `db:"content" json:"content"`
-	RetryKey      pgtype.UUID `db:"retry_key" json:"retry_key"`
+	BotID         int32              `db:"bot_id" json:"bot_id"`
+	MessageType   string             `db:"message_type" json:"message_type"`
+	RecipientType *string            `db:"recipient_type" json:"recipient_type"`
+	RecipientID   *string            `db:"recipient_id" json:"recipient_id"`
+	Content       []byte             `db:"content" json:"content"`
+	RetryKey      pgtype.UUID        `db:"retry_key" json:"retry_key"`
+	RequestID     *string            `db:"request_id" json:"request_id"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
 func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
@@ -53,6 +56,8 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		arg.RecipientID,
 		arg.Content,
 		arg.RetryKey,
+		arg.RequestID,
+		arg.CreatedAt,
 	)
 	var i Message
 	err := row.Scan(
@@ -63,13 +68,14 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		&i.RecipientID,
 		&i.Content,
 		&i.RetryKey,
+		&i.RequestID,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getBotMessages = `-- name: GetBotMessages :many
-SELECT id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, created_at FROM messages 
+SELECT id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, request_id, created_at FROM messages 
 WHERE bot_id = $1 
 ORDER BY created_at DESC 
 LIMIT $2 OFFSET $3
@@ -98,6 +104,7 @@ func (q *Queries) GetBotMessages(ctx context.Context, arg GetBotMessagesParams)
 			&i.RecipientID,
 			&i.Content,
 			&i.RetryKey,
+			&i.RequestID,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -110,12 +117,43 @@ func (q *Queries) GetBotMessages(ctx context.Context, arg GetBotMessagesParams)
 	return items, nil
 }
 
+const getMessage = `-- name: GetMessage :one
+SELECT id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, request_id, created_at FROM messages WHERE id = $1
+`
+
+func (q *Queries) GetMessage(ctx context.Context, id int32) (Message, error) {
+	row := q.db.QueryRow(ctx, getMessage, id)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.BotID,
+		&i.MessageType,
+		&i.RecipientType,
+		&i.RecipientID,
+		&i.Content,
+		&i.RetryKey,
+		&i.RequestID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getMessagesByRetryKey = `-- name: GetMessagesByRetryKey :one
-SELECT id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, created_at FROM messages WHERE retry_key = $1 LIMIT 1
+SELECT id, bot_id, message_type, recipient_type, recipient_id, content, retry_key, request_id, created_at FROM messages
+WHERE bot_id = $1 AND retry_key = $2 AND created_at > $3
+ORDER BY id DESC
+LIMIT 1
 `
 
-func (q *Queries) GetMessagesByRetryKey(ctx context.Context, retryKey pgtype.UUID) (Message, error) {
-	row := q.db.QueryRow(ctx, getMessagesByRetryKey, retryKey)
+type GetMessagesByRetryKeyParams struct {
+	BotID         int32              `db:"bot_id" json:"bot_id"`
+	RetryKey      pgtype.UUID        `db:"retry_key" json:"retry_key"`
+	AcceptedAfter pgtype.Timestamptz `db:"accepted_after" json:"accepted_after"`
+}
+
+// Retry keys are scoped to the bot. Messages sent before accepted_after are ignored so that retry keys expire.
+func (q *Queries) GetMessagesByRetryKey(ctx context.Context, arg GetMessagesByRetryKeyParams) (Message, error) {
+	row := q.db.QueryRow(ctx, getMessagesByRetryKey, arg.BotID, arg.RetryKey, arg.AcceptedAfter)
 	var i Message
 	err := row.Scan(
 		&i.ID,
@@ -125,7 +163,25 @@ func (q *Queries) GetMessagesByRetryKey(ctx context.Context, retryKey pgtype.UUI
 		&i.RecipientID,
 		&i.Content,
 		&i.RetryKey,
+		&i.RequestID,
 		&i.CreatedAt,
 	)
 	return i, err
 }
+
+const releaseExpiredRetryKey = `-- name: ReleaseExpiredRetryKey :exec
+UPDATE messages SET retry_key = NULL
+WHERE bot_id = $1 AND retry_key = $2 AND created_at <= $3
+`
+
+type ReleaseExpiredRetryKeyParams struct {
+	BotID         int32              `db:"bot_id" json:"bot_id"`
+	RetryKey      pgtype.UUID        `db:"retry_key" json:"retry_key"`
+	AcceptedUntil pgtype.Timestamptz `db:"accepted_until" json:"accepted_until"`
+}
+
+// Retry keys are unique per bot, so the retry key of a message accepted until accepted_until is cleared to be used again.
+func (q *Queries) ReleaseExpiredRetryKey(ctx context.Context, arg ReleaseExpiredRetryKeyParams) error {
+	_, err := q.db.Exec(ctx, releaseExpiredRetryKey, arg.BotID, arg.RetryKey, arg.AcceptedUntil)
+	return err
+}
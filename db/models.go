@@ -8,17 +8,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiCall struct {
+	ID              int32              `db:"id" json:"id"`
+	RequestID       string             `db:"request_id" json:"request_id"`
+	Method          string             `db:"method" json:"method"`
+	Path            string             `db:"path" json:"path"`
+	RequestHeaders  []byte             `db:"request_headers" json:"request_headers"`
+	RequestBody     *string            `db:"request_body" json:"request_body"`
+	StatusCode      int32              `db:"status_code" json:"status_code"`
+	ResponseHeaders []byte             `db:"response_headers" json:"response_headers"`
+	ResponseBody    *string            `db:"response_body" json:"response_body"`
+	LatencyMs       int32              `db:"latency_ms" json:"latency_ms"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Blob struct {
+	Key       string             `db:"key" json:"key"`
+	Data      []byte             `db:"data" json:"data"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type Bot struct {
-	ID             int32              `db:"id" json:"id"`
-	UserID         string             `db:"user_id" json:"user_id"`
-	BasicID        string             `db:"basic_id" json:"basic_id"`
-	ChatMode       string             `db:"chat_mode" json:"chat_mode"`
-	DisplayName    string             `db:"display_name" json:"display_name"`
-	MarkAsReadMode string             `db:"mark_as_read_mode" json:"mark_as_read_mode"`
-	PictureUrl     *string            `db:"picture_url" json:"picture_url"`
-	PremiumID      *string            `db:"premium_id" json:"premium_id"`
-	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID                int32              `db:"id" json:"id"`
+	UserID            string             `db:"user_id" json:"user_id"`
+	BasicID           string             `db:"basic_id" json:"basic_id"`
+	ChatMode          string             `db:"chat_mode" json:"chat_mode"`
+	DisplayName       string             `db:"display_name" json:"display_name"`
+	MarkAsReadMode    string             `db:"mark_as_read_mode" json:"mark_as_read_mode"`
+	PictureUrl        *string            `db:"picture_url" json:"picture_url"`
+	PremiumID         *string            `db:"premium_id" json:"premium_id"`
+	ChannelSecret     string             `db:"channel_secret" json:"channel_secret"`
+	WebhookRedelivery bool               `db:"webhook_redelivery" json:"webhook_redelivery"`
+	MessageQuotaPlan  string             `db:"message_quota_plan" json:"message_quota_plan"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type BotChat struct {
+	ID       int32              `db:"id" json:"id"`
+	BotID    int32              `db:"bot_id" json:"bot_id"`
+	ChatType string             `db:"chat_type" json:"chat_type"`
+	ChatID   string             `db:"chat_id" json:"chat_id"`
+	JoinedAt pgtype.Timestamptz `db:"joined_at" json:"joined_at"`
 }
 
 type BotFollower struct {
@@ -26,6 +57,28 @@ type BotFollower struct {
 	BotID      int32              `db:"bot_id" json:"bot_id"`
 	UserID     int32              `db:"user_id" json:"user_id"`
 	FollowedAt pgtype.Timestamptz `db:"followed_at" json:"followed_at"`
+	BlockedAt  pgtype.Timestamptz `db:"blocked_at" json:"blocked_at"`
+}
+
+type ConversationMessage struct {
+	ID        int32              `db:"id" json:"id"`
+	BotID     int32              `db:"bot_id" json:"bot_id"`
+	ChatType  string             `db:"chat_type" json:"chat_type"`
+	ChatID    string             `db:"chat_id" json:"chat_id"`
+	Sender    string             `db:"sender" json:"sender"`
+	MessageID *int32             `db:"message_id" json:"message_id"`
+	Content   []byte             `db:"content" json:"content"`
+	ReadAt    pgtype.Timestamptz `db:"read_at" json:"read_at"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type LoadingAnimation struct {
+	ID             int32              `db:"id" json:"id"`
+	BotID          int32              `db:"bot_id" json:"bot_id"`
+	ChatID         string             `db:"chat_id" json:"chat_id"`
+	LoadingSeconds int32              `db:"loading_seconds" json:"loading_seconds"`
+	StartedAt      pgtype.Timestamptz `db:"started_at" json:"started_at"`
+	ExpiresAt      pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
 }
 
 type Message struct {
@@ -36,6 +89,77 @@ type Message struct {
 	RecipientID   *string            `db:"recipient_id" json:"recipient_id"`
 	Content       []byte             `db:"content" json:"content"`
 	RetryKey      pgtype.UUID        `db:"retry_key" json:"retry_key"`
+	RequestID     *string            `db:"request_id" json:"request_id"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type MessageAggregationUnit struct {
+	ID        int32              `db:"id" json:"id"`
+	MessageID int32              `db:"message_id" json:"message_id"`
+	BotID     int32              `db:"bot_id" json:"bot_id"`
+	Name      string             `db:"name" json:"name"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type MessageContent struct {
+	ID                     int32              `db:"id" json:"id"`
+	BotID                  int32              `db:"bot_id" json:"bot_id"`
+	MessageID              string             `db:"message_id" json:"message_id"`
+	MessageType            string             `db:"message_type" json:"message_type"`
+	ContentType            string             `db:"content_type" json:"content_type"`
+	BlobKey                string             `db:"blob_key" json:"blob_key"`
+	PreviewBlobKey         *string            `db:"preview_blob_key" json:"preview_blob_key"`
+	Size                   int64              `db:"size" json:"size"`
+	TranscodingCompletedAt pgtype.Timestamptz `db:"transcoding_completed_at" json:"transcoding_completed_at"`
+	TranscodingResult      string             `db:"transcoding_result" json:"transcoding_result"`
+	CreatedAt              pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type MessageDelivery struct {
+	ID            int32              `db:"id" json:"id"`
+	MessageID     int32              `db:"message_id" json:"message_id"`
+	RecipientType string             `db:"recipient_type" json:"recipient_type"`
+	RecipientID   string             `db:"recipient_id" json:"recipient_id"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type NarrowcastJob struct {
+	ID                 int32              `db:"id" json:"id"`
+	BotID              int32              `db:"bot_id" json:"bot_id"`
+	MessageID          int32              `db:"message_id" json:"message_id"`
+	RequestID          string             `db:"request_id" json:"request_id"`
+	Filter             []byte             `db:"filter" json:"filter"`
+	LimitMax           *int32             `db:"limit_max" json:"limit_max"`
+	UpToRemainingQuota bool               `db:"up_to_remaining_quota" json:"up_to_remaining_quota"`
+	Phase              string             `db:"phase" json:"phase"`
+	TargetCount        *int32             `db:"target_count" json:"target_count"`
+	SuccessCount       *int32             `db:"success_count" json:"success_count"`
+	FailureCount       *int32             `db:"failure_count" json:"failure_count"`
+	ErrorCode          *int32             `db:"error_code" json:"error_code"`
+	FailedDescription  *string            `db:"failed_description" json:"failed_description"`
+	LeaseUntil         pgtype.Timestamptz `db:"lease_until" json:"lease_until"`
+	AcceptedAt         pgtype.Timestamptz `db:"accepted_at" json:"accepted_at"`
+	CompletedAt        pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+}
+
+type ReplyToken struct {
+	ID             int32              `db:"id" json:"id"`
+	BotID          int32              `db:"bot_id" json:"bot_id"`
+	Token          string             `db:"token" json:"token"`
+	WebhookEventID string             `db:"webhook_event_id" json:"webhook_event_id"`
+	SourceType     string             `db:"source_type" json:"source_type"`
+	SourceID       string             `db:"source_id" json:"source_id"`
+	IssuedAt       pgtype.Timestamptz `db:"issued_at" json:"issued_at"`
+	UsedAt         pgtype.Timestamptz `db:"used_at" json:"used_at"`
+}
+
+type SentMessage struct {
+	ID            int32              `db:"id" json:"id"`
+	MessageID     int32              `db:"message_id" json:"message_id"`
+	BotID         int32              `db:"bot_id" json:"bot_id"`
+	Position      int32              `db:"position" json:"position"`
+	LineMessageID string             `db:"line_message_id" json:"line_message_id"`
+	QuoteToken    string             `db:"quote_token" json:"quote_token"`
 	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
@@ -46,10 +170,23 @@ type User struct {
 	PictureUrl    *string            `db:"picture_url" json:"picture_url"`
 	StatusMessage *string            `db:"status_message" json:"status_message"`
 	Language      *string            `db:"language" json:"language"`
+	Gender        *string            `db:"gender" json:"gender"`
+	Age           *int32             `db:"age" json:"age"`
+	AppType       *string            `db:"app_type" json:"app_type"`
+	Area          *string            `db:"area" json:"area"`
 	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type UserPhoneNumber struct {
+	ID              int32              `db:"id" json:"id"`
+	UserID          int32              `db:"user_id" json:"user_id"`
+	PhoneNumber     string             `db:"phone_number" json:"phone_number"`
+	PhoneNumberHash string             `db:"phone_number_hash" json:"phone_number_hash"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type Webhook struct {
 	ID        int32              `db:"id" json:"id"`
 	BotID     int32              `db:"bot_id" json:"bot_id"`
@@ -58,3 +195,30 @@ type Webhook struct {
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
+
+type WebhookDelivery struct {
+	ID             int32              `db:"id" json:"id"`
+	BotID          int32              `db:"bot_id" json:"bot_id"`
+	WebhookEventID *string            `db:"webhook_event_id" json:"webhook_event_id"`
+	Endpoint       string             `db:"endpoint" json:"endpoint"`
+	RequestHeaders []byte             `db:"request_headers" json:"request_headers"`
+	RequestBody    string             `db:"request_body" json:"request_body"`
+	StatusCode     *int32             `db:"status_code" json:"status_code"`
+	ResponseBody   *string            `db:"response_body" json:"response_body"`
+	LatencyMs      int32              `db:"latency_ms" json:"latency_ms"`
+	Error          *string            `db:"error" json:"error"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type WebhookEvent struct {
+	ID             int32              `db:"id" json:"id"`
+	BotID          int32              `db:"bot_id" json:"bot_id"`
+	WebhookEventID string             `db:"webhook_event_id" json:"webhook_event_id"`
+	Payload        []byte             `db:"payload" json:"payload"`
+	Status         string             `db:"status" json:"status"`
+	Attempts       int32              `db:"attempts" json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError      *string            `db:"last_error" json:"last_error"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
@@ -6,37 +6,98 @@ package db
 
 import (
 	"context"
-
-	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	BlockBotFollower(ctx context.Context, arg BlockBotFollowerParams) (BotFollower, error)
+	ClaimNarrowcastJobs(ctx context.Context, arg ClaimNarrowcastJobsParams) ([]NarrowcastJob, error)
+	ClaimWebhookEvents(ctx context.Context, arg ClaimWebhookEventsParams) ([]WebhookEvent, error)
+	CompleteNarrowcastJob(ctx context.Context, arg CompleteNarrowcastJobParams) error
+	// Counts the distinct aggregation units the bot used in [used_from, used_until)
+	CountBotAggregationUnits(ctx context.Context, arg CountBotAggregationUnitsParams) (int64, error)
+	// Counts the deliveries of the bot's messages of the type sent in [sent_from, sent_until)
+	CountBotMessageDeliveries(ctx context.Context, arg CountBotMessageDeliveriesParams) (int64, error)
+	// Counts the deliveries of the bot's messages sent in [sent_from, sent_until) with the APIs which consume the message quota
+	CountBotMessageQuotaConsumption(ctx context.Context, arg CountBotMessageQuotaConsumptionParams) (int64, error)
 	CountBotMessages(ctx context.Context, botID int32) (int64, error)
+	CreateApiCall(ctx context.Context, arg CreateApiCallParams) (ApiCall, error)
 	CreateBot(ctx context.Context, arg CreateBotParams) (Bot, error)
+	CreateBotChat(ctx context.Context, arg CreateBotChatParams) (BotChat, error)
 	CreateBotFollower(ctx context.Context, arg CreateBotFollowerParams) (BotFollower, error)
 	CreateBotFollowers(ctx context.Context, arg []CreateBotFollowersParams) (int64, error)
+	CreateConversationMessages(ctx context.Context, arg []CreateConversationMessagesParams) (int64, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	CreateMessageAggregationUnit(ctx context.Context, arg CreateMessageAggregationUnitParams) error
+	CreateMessageContent(ctx context.Context, arg CreateMessageContentParams) (MessageContent, error)
+	CreateMessageDeliveries(ctx context.Context, arg []CreateMessageDeliveriesParams) (int64, error)
+	CreateNarrowcastJob(ctx context.Context, arg CreateNarrowcastJobParams) (NarrowcastJob, error)
+	CreateReplyToken(ctx context.Context, arg CreateReplyTokenParams) (ReplyToken, error)
+	CreateSentMessages(ctx context.Context, arg []CreateSentMessagesParams) (int64, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateUsers(ctx context.Context, arg []CreateUsersParams) (int64, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEvent(ctx context.Context, arg CreateWebhookEventParams) (WebhookEvent, error)
 	DeleteBot(ctx context.Context, userID string) error
+	// Clears the loading indicators in the chats the bot sent messages to
+	DeleteLoadingAnimations(ctx context.Context, arg DeleteLoadingAnimationsParams) error
+	GetActiveLoadingAnimation(ctx context.Context, arg GetActiveLoadingAnimationParams) (LoadingAnimation, error)
+	GetAllBotFollowerUserIDs(ctx context.Context, botID int32) ([]string, error)
+	GetApiCallByRequestID(ctx context.Context, requestID string) (ApiCall, error)
+	GetBlob(ctx context.Context, key string) ([]byte, error)
+	GetBlockedUserIDs(ctx context.Context, arg GetBlockedUserIDsParams) ([]string, error)
 	GetBot(ctx context.Context, id int32) (Bot, error)
 	GetBotByBasicID(ctx context.Context, basicID string) (Bot, error)
 	GetBotByUserID(ctx context.Context, userID string) (Bot, error)
+	GetBotFollower(ctx context.Context, arg GetBotFollowerParams) (BotFollower, error)
+	GetBotFollowerAttributes(ctx context.Context, botID int32) ([]GetBotFollowerAttributesRow, error)
 	GetBotFollowerCount(ctx context.Context, botID int32) (int64, error)
 	GetBotFollowerUser(ctx context.Context, arg GetBotFollowerUserParams) (User, error)
 	GetBotFollowerUserIDs(ctx context.Context, arg GetBotFollowerUserIDsParams) ([]string, error)
 	GetBotFollowers(ctx context.Context, arg GetBotFollowersParams) ([]User, error)
 	GetBotMessages(ctx context.Context, arg GetBotMessagesParams) ([]Message, error)
-	GetMessagesByRetryKey(ctx context.Context, retryKey pgtype.UUID) (Message, error)
+	GetFollowingUserIDs(ctx context.Context, arg GetFollowingUserIDsParams) ([]string, error)
+	GetMessage(ctx context.Context, id int32) (Message, error)
+	GetMessageContent(ctx context.Context, arg GetMessageContentParams) (MessageContent, error)
+	GetMessagesByRetryKey(ctx context.Context, arg GetMessagesByRetryKeyParams) (Message, error)
+	GetNarrowcastJobByRequestID(ctx context.Context, arg GetNarrowcastJobByRequestIDParams) (NarrowcastJob, error)
 	GetUser(ctx context.Context, userID string) (User, error)
 	GetUserByID(ctx context.Context, id int32) (User, error)
+	GetUserByPhoneNumberHash(ctx context.Context, phoneNumberHash string) (User, error)
 	GetUsersByUserIDs(ctx context.Context, dollar_1 []string) ([]User, error)
 	GetWebhook(ctx context.Context, botID int32) (GetWebhookRow, error)
 	GetWebhookByBotID(ctx context.Context, botID int32) (GetWebhookByBotIDRow, error)
+	GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error)
+	GetWebhookEventByWebhookEventID(ctx context.Context, webhookEventID string) (WebhookEvent, error)
+	IsBotAggregationUnitUsed(ctx context.Context, arg IsBotAggregationUnitUsedParams) (bool, error)
+	IsBotChatMember(ctx context.Context, arg IsBotChatMemberParams) (bool, error)
 	IsBotFollower(ctx context.Context, arg IsBotFollowerParams) (bool, error)
+	// Lists the distinct aggregation units the bot used in [used_from, used_until) in the order they were first used
+	ListBotAggregationUnitNames(ctx context.Context, arg ListBotAggregationUnitNamesParams) ([]string, error)
 	ListBots(ctx context.Context) ([]Bot, error)
+	ListConversationMessages(ctx context.Context, arg ListConversationMessagesParams) ([]ListConversationMessagesRow, error)
+	ListMessageDeliveries(ctx context.Context, messageID int32) ([]MessageDelivery, error)
+	ListSentMessages(ctx context.Context, messageID int32) ([]SentMessage, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Marks the unread messages the user sent in the chat as read
+	MarkConversationMessagesRead(ctx context.Context, arg MarkConversationMessagesReadParams) error
+	MarkWebhookEventDelivered(ctx context.Context, id int32) error
+	MarkWebhookEventFailed(ctx context.Context, arg MarkWebhookEventFailedParams) error
+	PutBlob(ctx context.Context, arg PutBlobParams) error
+	// Quote tokens can be used by the bot they were issued to: the bot sent the message, or a user sent it to the bot.
+	QuoteTokenExists(ctx context.Context, arg QuoteTokenExistsParams) (bool, error)
+	// Retry keys are unique per bot, so the retry key of a message accepted until accepted_until is cleared to be used again.
+	ReleaseExpiredRetryKey(ctx context.Context, arg ReleaseExpiredRetryKeyParams) error
+	RetryWebhookEvent(ctx context.Context, arg RetryWebhookEventParams) error
+	SetNarrowcastJobTargetCount(ctx context.Context, arg SetNarrowcastJobTargetCountParams) error
 	UpdateBot(ctx context.Context, arg UpdateBotParams) (Bot, error)
+	UpdateBotMessageQuotaPlan(ctx context.Context, arg UpdateBotMessageQuotaPlanParams) (Bot, error)
+	UpdateBotWebhookRedelivery(ctx context.Context, arg UpdateBotWebhookRedeliveryParams) (Bot, error)
+	UpdateUserAttributes(ctx context.Context, arg UpdateUserAttributesParams) (User, error)
+	UpsertBotFollower(ctx context.Context, arg UpsertBotFollowerParams) (BotFollower, error)
+	UpsertLoadingAnimation(ctx context.Context, arg UpsertLoadingAnimationParams) (LoadingAnimation, error)
+	UpsertUserPhoneNumber(ctx context.Context, arg UpsertUserPhoneNumberParams) (UserPhoneNumber, error)
 	UpsertWebhook(ctx context.Context, arg UpsertWebhookParams) error
+	UseReplyToken(ctx context.Context, arg UseReplyTokenParams) (ReplyToken, error)
 }
 
 var _ Querier = (*Queries)(nil)
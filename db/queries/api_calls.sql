@@ -0,0 +1,26 @@
+-- name: CreateApiCall :one
+INSERT INTO api_calls (
+    request_id,
+    method,
+    path,
+    request_headers,
+    request_body,
+    status_code,
+    response_headers,
+    response_body,
+    latency_ms
+) VALUES (
+    @request_id,
+    @method,
+    @path,
+    @request_headers,
+    @request_body,
+    @status_code,
+    @response_headers,
+    @response_body,
+    @latency_ms
+) RETURNING *;
+
+-- name: GetApiCallByRequestID :one
+SELECT * FROM api_calls
+WHERE request_id = @request_id;
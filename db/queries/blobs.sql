@@ -0,0 +1,6 @@
+-- name: PutBlob :exec
+INSERT INTO blobs (key, data) VALUES (@key, @data)
+ON CONFLICT (key) DO UPDATE SET data = EXCLUDED.data;
+
+-- name: GetBlob :one
+SELECT data FROM blobs WHERE key = @key;
@@ -0,0 +1,14 @@
+-- name: CreateBotChat :one
+INSERT INTO bot_chats (
+    bot_id,
+    chat_type,
+    chat_id
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: IsBotChatMember :one
+SELECT EXISTS (
+    SELECT 1 FROM bot_chats
+    WHERE bot_id = $1 AND chat_type = $2 AND chat_id = $3
+);
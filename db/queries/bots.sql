@@ -6,7 +6,9 @@ INSERT INTO bots (
     display_name,
     mark_as_read_mode,
     picture_url,
-    premium_id
+    premium_id,
+    channel_secret,
+    webhook_redelivery
 ) VALUES (
           @user_id,
             @basic_id,
@@ -14,7 +16,9 @@ INSERT INTO bots (
             @display_name,
           @mark_as_read_mode,
             @picture_url,
-            @premium_id
+            @premium_id,
+            @channel_secret,
+            @webhook_redelivery
 ) RETURNING *;
 
 -- name: GetBot :one
@@ -46,6 +50,22 @@ SET
 WHERE user_id = @user_id
 RETURNING *;
 
+-- name: UpdateBotWebhookRedelivery :one
+UPDATE bots
+SET
+    webhook_redelivery = @webhook_redelivery,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+RETURNING *;
+
+-- name: UpdateBotMessageQuotaPlan :one
+UPDATE bots
+SET
+    message_quota_plan = @message_quota_plan,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+RETURNING *;
+
 -- name: DeleteBot :exec
 DELETE FROM bots
 WHERE user_id = @user_id;
\ No newline at end of file
@@ -0,0 +1,28 @@
+-- name: CreateConversationMessages :copyfrom
+INSERT INTO conversation_messages (
+    bot_id,
+    chat_type,
+    chat_id,
+    sender,
+    message_id,
+    content,
+    read_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListConversationMessages :many
+SELECT cm.id, cm.chat_type, cm.chat_id, cm.sender, cm.content, cm.read_at, cm.created_at, m.message_type
+FROM conversation_messages cm
+LEFT JOIN messages m ON m.id = cm.message_id
+WHERE cm.bot_id = $1 AND cm.chat_id = $2
+ORDER BY cm.id;
+
+-- name: MarkConversationMessagesRead :exec
+-- Marks the unread messages the user sent in the chat as read
+UPDATE conversation_messages
+SET read_at = @read_at
+WHERE bot_id = @bot_id
+  AND chat_id = @chat_id
+  AND sender = 'user'
+  AND read_at IS NULL;
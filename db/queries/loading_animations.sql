@@ -0,0 +1,28 @@
+-- name: UpsertLoadingAnimation :one
+INSERT INTO loading_animations (
+    bot_id,
+    chat_id,
+    loading_seconds,
+    started_at,
+    expires_at
+) VALUES (
+    @bot_id,
+    @chat_id,
+    @loading_seconds,
+    @started_at,
+    @expires_at
+)
+ON CONFLICT (bot_id, chat_id) DO UPDATE SET
+    loading_seconds = EXCLUDED.loading_seconds,
+    started_at = EXCLUDED.started_at,
+    expires_at = EXCLUDED.expires_at
+RETURNING *;
+
+-- name: GetActiveLoadingAnimation :one
+SELECT * FROM loading_animations
+WHERE bot_id = @bot_id AND chat_id = @chat_id AND expires_at > @now;
+
+-- name: DeleteLoadingAnimations :exec
+-- Clears the loading indicators in the chats the bot sent messages to
+DELETE FROM loading_animations
+WHERE bot_id = @bot_id AND chat_id = ANY(@chat_ids::text[]);
@@ -0,0 +1,38 @@
+-- name: CreateMessageAggregationUnit :exec
+INSERT INTO message_aggregation_units (
+    message_id,
+    bot_id,
+    name,
+    created_at
+) VALUES (
+    @message_id,
+    @bot_id,
+    @name,
+    @created_at
+);
+
+-- name: CountBotAggregationUnits :one
+-- Counts the distinct aggregation units the bot used in [used_from, used_until)
+SELECT COUNT(DISTINCT name) FROM message_aggregation_units
+WHERE bot_id = @bot_id
+  AND created_at >= @used_from
+  AND created_at < @used_until;
+
+-- name: ListBotAggregationUnitNames :many
+-- Lists the distinct aggregation units the bot used in [used_from, used_until) in the order they were first used
+SELECT name FROM message_aggregation_units
+WHERE bot_id = @bot_id
+  AND created_at >= @used_from
+  AND created_at < @used_until
+GROUP BY name
+ORDER BY MIN(id)
+LIMIT @max_count OFFSET @skip_count;
+
+-- name: IsBotAggregationUnitUsed :one
+SELECT EXISTS (
+    SELECT 1 FROM message_aggregation_units
+    WHERE bot_id = @bot_id
+      AND name = @name
+      AND created_at >= @used_from
+      AND created_at < @used_until
+);
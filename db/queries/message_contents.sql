@@ -0,0 +1,27 @@
+-- name: CreateMessageContent :one
+INSERT INTO message_contents (
+    bot_id,
+    message_id,
+    message_type,
+    content_type,
+    blob_key,
+    preview_blob_key,
+    size,
+    transcoding_completed_at,
+    transcoding_result
+) VALUES (
+    @bot_id,
+    @message_id,
+    @message_type,
+    @content_type,
+    @blob_key,
+    @preview_blob_key,
+    @size,
+    @transcoding_completed_at,
+    @transcoding_result
+)
+RETURNING *;
+
+-- name: GetMessageContent :one
+SELECT * FROM message_contents
+WHERE bot_id = @bot_id AND message_id = @message_id;
@@ -0,0 +1,31 @@
+-- name: CreateMessageDeliveries :copyfrom
+INSERT INTO message_deliveries (
+    message_id,
+    recipient_type,
+    recipient_id
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: ListMessageDeliveries :many
+SELECT * FROM message_deliveries
+WHERE message_id = $1
+ORDER BY id;
+
+-- name: CountBotMessageDeliveries :one
+-- Counts the deliveries of the bot's messages of the type sent in [sent_from, sent_until)
+SELECT COUNT(*) FROM message_deliveries md
+INNER JOIN messages m ON m.id = md.message_id
+WHERE m.bot_id = @bot_id
+  AND m.message_type = @message_type
+  AND m.created_at >= @sent_from
+  AND m.created_at < @sent_until;
+
+-- name: CountBotMessageQuotaConsumption :one
+-- Counts the deliveries of the bot's messages sent in [sent_from, sent_until) with the APIs which consume the message quota
+SELECT COUNT(*) FROM message_deliveries md
+INNER JOIN messages m ON m.id = md.message_id
+WHERE m.bot_id = @bot_id
+  AND m.message_type IN ('push', 'multicast', 'broadcast', 'narrowcast')
+  AND m.created_at >= @sent_from
+  AND m.created_at < @sent_until;
@@ -6,13 +6,23 @@ INSERT INTO messages (
     recipient_id,
     content,
     retry_key,
+    request_id,
     created_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetMessagesByRetryKey :one
-SELECT * FROM messages WHERE retry_key = $1 LIMIT 1;
+-- Retry keys are scoped to the bot. Messages sent before accepted_after are ignored so that retry keys expire.
+SELECT * FROM messages
+WHERE bot_id = @bot_id AND retry_key = @retry_key AND created_at > @accepted_after
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ReleaseExpiredRetryKey :exec
+-- Retry keys are unique per bot, so the retry key of a message accepted until accepted_until is cleared to be used again.
+UPDATE messages SET retry_key = NULL
+WHERE bot_id = @bot_id AND retry_key = @retry_key AND created_at <= @accepted_until;
 
 -- name: GetBotMessages :many
 SELECT * FROM messages 
@@ -21,4 +31,6 @@ ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: CountBotMessages :one
-SELECT COUNT(*) FROM messages WHERE bot_id = $1;
\ No newline at end of file
+SELECT COUNT(*) FROM messages WHERE bot_id = $1;
+-- name: GetMessage :one
+SELECT * FROM messages WHERE id = $1;
@@ -0,0 +1,56 @@
+-- name: CreateNarrowcastJob :one
+INSERT INTO narrowcast_jobs (
+    bot_id,
+    message_id,
+    request_id,
+    filter,
+    limit_max,
+    up_to_remaining_quota,
+    accepted_at
+) VALUES (
+    @bot_id,
+    @message_id,
+    @request_id,
+    @filter,
+    @limit_max,
+    @up_to_remaining_quota,
+    @accepted_at
+) RETURNING *;
+
+-- name: GetNarrowcastJobByRequestID :one
+SELECT * FROM narrowcast_jobs
+WHERE bot_id = @bot_id AND request_id = @request_id;
+
+-- name: ClaimNarrowcastJobs :many
+-- Picks up waiting jobs and leases them until lease_until so that other workers skip them.
+-- Jobs whose lease has expired (e.g. the worker crashed) are picked up again.
+UPDATE narrowcast_jobs
+SET
+    phase = 'sending',
+    lease_until = @lease_until
+WHERE id IN (
+    SELECT id FROM narrowcast_jobs
+    WHERE phase = 'waiting'
+       OR (phase = 'sending' AND lease_until <= @now)
+    ORDER BY id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SetNarrowcastJobTargetCount :exec
+UPDATE narrowcast_jobs
+SET target_count = @target_count
+WHERE id = @id;
+
+-- name: CompleteNarrowcastJob :exec
+UPDATE narrowcast_jobs
+SET
+    phase = @phase,
+    success_count = @success_count,
+    failure_count = @failure_count,
+    error_code = @error_code,
+    failed_description = @failed_description,
+    lease_until = NULL,
+    completed_at = @completed_at
+WHERE id = @id;
@@ -0,0 +1,26 @@
+-- name: CreateReplyToken :one
+INSERT INTO reply_tokens (
+    bot_id,
+    token,
+    webhook_event_id,
+    source_type,
+    source_id,
+    issued_at
+) VALUES (
+    @bot_id,
+    @token,
+    @webhook_event_id,
+    @source_type,
+    @source_id,
+    @issued_at
+) RETURNING *;
+
+-- name: UseReplyToken :one
+-- Marks the reply token as used unless it was issued to another bot, has been used or was issued before issued_after.
+UPDATE reply_tokens
+SET used_at = @used_at
+WHERE token = @token
+  AND bot_id = @bot_id
+  AND used_at IS NULL
+  AND issued_at > @issued_after
+RETURNING *;
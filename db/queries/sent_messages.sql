@@ -0,0 +1,25 @@
+-- name: CreateSentMessages :copyfrom
+INSERT INTO sent_messages (
+    message_id,
+    bot_id,
+    position,
+    line_message_id,
+    quote_token
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: ListSentMessages :many
+SELECT * FROM sent_messages
+WHERE message_id = $1
+ORDER BY position;
+
+-- name: QuoteTokenExists :one
+-- Quote tokens can be used by the bot they were issued to: the bot sent the message, or a user sent it to the bot.
+SELECT EXISTS (
+    SELECT 1 FROM sent_messages sm
+    WHERE sm.bot_id = @bot_id AND sm.quote_token = @quote_token
+) OR EXISTS (
+    SELECT 1 FROM conversation_messages cm
+    WHERE cm.bot_id = @bot_id AND cm.sender = 'user' AND cm.content->>'quoteToken' = @quote_token::text
+);
@@ -0,0 +1,20 @@
+-- name: UpsertUserPhoneNumber :one
+INSERT INTO user_phone_numbers (
+    user_id,
+    phone_number,
+    phone_number_hash
+) VALUES (
+    @user_id,
+    @phone_number,
+    @phone_number_hash
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    phone_number = EXCLUDED.phone_number,
+    phone_number_hash = EXCLUDED.phone_number_hash,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetUserByPhoneNumberHash :one
+SELECT u.* FROM users u
+INNER JOIN user_phone_numbers p ON u.id = p.user_id
+WHERE p.phone_number_hash = @phone_number_hash;
@@ -27,7 +27,7 @@ WHERE user_id = $1;
 -- name: GetBotFollowerUser :one
 SELECT u.* FROM users u
 INNER JOIN bot_followers bf ON u.id = bf.user_id
-WHERE bf.bot_id = $1 AND u.user_id = $2;
+WHERE bf.bot_id = $1 AND u.user_id = $2 AND bf.blocked_at IS NULL;
 
 -- name: GetUserByID :one
 SELECT * FROM users WHERE id = $1;
@@ -54,23 +54,79 @@ INSERT INTO bot_followers (
 -- name: GetBotFollowers :many
 SELECT u.* FROM users u
 INNER JOIN bot_followers bf ON u.id = bf.user_id
-WHERE bf.bot_id = $1
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
 ORDER BY bf.followed_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetBotFollowerCount :one
-SELECT COUNT(*) FROM bot_followers WHERE bot_id = $1;
+SELECT COUNT(*) FROM bot_followers WHERE bot_id = $1 AND blocked_at IS NULL;
 
 -- name: GetBotFollowerUserIDs :many
 SELECT u.user_id FROM users u
 INNER JOIN bot_followers bf ON u.id = bf.user_id
-WHERE bf.bot_id = $1
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
 ORDER BY bf.followed_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetAllBotFollowerUserIDs :many
+SELECT u.user_id FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
+ORDER BY bf.id;
+
 -- name: IsBotFollower :one
 SELECT EXISTS (
     SELECT 1 FROM bot_followers bf
     INNER JOIN users u ON u.id = bf.user_id
-    WHERE bf.bot_id = $1 AND u.user_id = $2
-);
\ No newline at end of file
+    WHERE bf.bot_id = $1 AND u.user_id = $2 AND bf.blocked_at IS NULL
+);
+
+-- name: GetBotFollower :one
+SELECT * FROM bot_followers
+WHERE bot_id = $1 AND user_id = $2;
+
+-- name: UpsertBotFollower :one
+INSERT INTO bot_followers (
+    bot_id,
+    user_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (bot_id, user_id)
+DO UPDATE SET
+    blocked_at = NULL,
+    followed_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: BlockBotFollower :one
+UPDATE bot_followers
+SET blocked_at = CURRENT_TIMESTAMP
+WHERE bot_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetBlockedUserIDs :many
+SELECT u.user_id FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = @bot_id AND bf.blocked_at IS NOT NULL AND u.user_id = ANY(@user_ids::text[]);
+
+-- name: GetFollowingUserIDs :many
+SELECT u.user_id FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = @bot_id AND bf.blocked_at IS NULL AND u.user_id = ANY(@user_ids::text[]);
+
+-- name: GetBotFollowerAttributes :many
+SELECT u.user_id, u.gender, u.age, u.app_type, u.area, bf.followed_at FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
+ORDER BY bf.id;
+
+-- name: UpdateUserAttributes :one
+UPDATE users
+SET
+    gender = @gender,
+    age = @age,
+    app_type = @app_type,
+    area = @area,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+RETURNING *;
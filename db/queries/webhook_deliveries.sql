@@ -0,0 +1,33 @@
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    bot_id,
+    webhook_event_id,
+    endpoint,
+    request_headers,
+    request_body,
+    status_code,
+    response_body,
+    latency_ms,
+    error
+) VALUES (
+    @bot_id,
+    @webhook_event_id,
+    @endpoint,
+    @request_headers,
+    @request_body,
+    @status_code,
+    @response_body,
+    @latency_ms,
+    @error
+) RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = @id AND bot_id = @bot_id;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE bot_id = @bot_id
+  AND (sqlc.narg('webhook_event_id')::text IS NULL OR webhook_event_id = sqlc.narg('webhook_event_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
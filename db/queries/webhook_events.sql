@@ -0,0 +1,60 @@
+-- name: CreateWebhookEvent :one
+INSERT INTO webhook_events (
+    bot_id,
+    webhook_event_id,
+    payload,
+    next_attempt_at
+) VALUES (
+    @bot_id,
+    @webhook_event_id,
+    @payload,
+    @next_attempt_at
+) RETURNING *;
+
+-- name: GetWebhookEventByWebhookEventID :one
+SELECT * FROM webhook_events
+WHERE webhook_event_id = @webhook_event_id;
+
+-- name: ClaimWebhookEvents :many
+-- Picks up due events and leases them until lease_until so that other workers skip them.
+-- Events whose lease has expired (e.g. the worker crashed) are picked up again.
+UPDATE webhook_events
+SET
+    status = 'delivering',
+    attempts = attempts + 1,
+    next_attempt_at = @lease_until,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT id FROM webhook_events
+    WHERE status IN ('pending', 'delivering')
+      AND next_attempt_at <= @now
+    ORDER BY next_attempt_at, id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookEventDelivered :exec
+UPDATE webhook_events
+SET
+    status = 'delivered',
+    last_error = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: MarkWebhookEventFailed :exec
+UPDATE webhook_events
+SET
+    status = 'failed',
+    last_error = @last_error,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: RetryWebhookEvent :exec
+UPDATE webhook_events
+SET
+    status = 'pending',
+    next_attempt_at = @next_attempt_at,
+    last_error = @last_error,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
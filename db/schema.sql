@@ -8,6 +8,9 @@ CREATE TABLE IF NOT EXISTS bots (
     mark_as_read_mode VARCHAR(10) NOT NULL CHECK (mark_as_read_mode IN ('auto', 'manual')),
     picture_url TEXT,
     premium_id VARCHAR(255),
+    channel_secret VARCHAR(255) NOT NULL DEFAULT md5(random()::text), -- The default fills in a random secret for bots created before the column was added
+    webhook_redelivery BOOLEAN NOT NULL DEFAULT false,
+    message_quota_plan VARCHAR(10) NOT NULL DEFAULT 'none' CHECK (message_quota_plan IN ('free', 'light', 'standard', 'none')), -- Monthly limit of messages. 'none' if unlimited
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -26,6 +29,10 @@ CREATE TABLE IF NOT EXISTS users (
     picture_url TEXT,
     status_message TEXT,
     language VARCHAR(10),
+    gender VARCHAR(10) CHECK (gender IN ('male', 'female')), -- Demographic attributes narrowcast filters are evaluated against. NULL if unknown
+    age INTEGER,
+    app_type VARCHAR(10) CHECK (app_type IN ('ios', 'android')),
+    area VARCHAR(10), -- Area code, e.g. jp_13
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -33,12 +40,23 @@ CREATE TABLE IF NOT EXISTS users (
 -- Create index on user_id for faster lookups
 CREATE INDEX idx_users_user_id ON users(user_id);
 
+-- Create user_phone_numbers table for the phone numbers users receive phone number push (PNP) messages at
+CREATE TABLE IF NOT EXISTS user_phone_numbers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) UNIQUE NOT NULL, -- E.164 format
+    phone_number_hash VARCHAR(64) UNIQUE NOT NULL, -- Hex encoded SHA-256 hash of phone_number, which PNP requests are addressed to
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Create bot_followers table for many-to-many relationship
 CREATE TABLE IF NOT EXISTS bot_followers (
     id SERIAL PRIMARY KEY,
     bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
     user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     followed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    blocked_at TIMESTAMP WITH TIME ZONE, -- NULL unless the user has blocked the bot
     UNIQUE(bot_id, user_id)
 );
 
@@ -46,6 +64,16 @@ CREATE TABLE IF NOT EXISTS bot_followers (
 CREATE INDEX idx_bot_followers_bot_id ON bot_followers(bot_id);
 CREATE INDEX idx_bot_followers_user_id ON bot_followers(user_id);
 
+-- Create bot_chats table for the group chats and multi-person chats (rooms) bots are members of
+CREATE TABLE IF NOT EXISTS bot_chats (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    chat_type VARCHAR(10) NOT NULL CHECK (chat_type IN ('group', 'room')),
+    chat_id VARCHAR(255) NOT NULL, -- group_id or room_id
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(bot_id, chat_id)
+);
+
 -- Create webhooks table for bot webhook configurations
 CREATE TABLE IF NOT EXISTS webhooks (
     id SERIAL PRIMARY KEY,
@@ -60,19 +88,194 @@ CREATE TABLE IF NOT EXISTS webhooks (
 -- Create index on bot_id for faster lookups
 CREATE INDEX idx_webhooks_bot_id ON webhooks(bot_id);
 
+-- Create webhook_events table for queuing webhook events to be delivered to bots
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    webhook_event_id VARCHAR(255) UNIQUE NOT NULL,
+    payload JSONB NOT NULL, -- The webhook event object
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivering', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for picking up due webhook events
+CREATE INDEX idx_webhook_events_status_next_attempt_at ON webhook_events(status, next_attempt_at);
+
+-- Create reply_tokens table for reply tokens issued with webhook events
+CREATE TABLE IF NOT EXISTS reply_tokens (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    token VARCHAR(255) UNIQUE NOT NULL,
+    webhook_event_id VARCHAR(255) NOT NULL,
+    source_type VARCHAR(10) NOT NULL CHECK (source_type IN ('user', 'group', 'room')),
+    source_id VARCHAR(255) NOT NULL, -- user_id, group_id or room_id of the chat the event occurred in
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create webhook_deliveries table for logging every webhook request sent to bots
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    webhook_event_id VARCHAR(255), -- NULL for requests sent by the test webhook endpoint API
+    endpoint TEXT NOT NULL,
+    request_headers JSONB NOT NULL,
+    request_body TEXT NOT NULL, -- Kept as sent so that the signature can be verified
+    status_code INTEGER, -- NULL if the request didn't reach the bot server
+    response_body TEXT, -- Beginning of the response body
+    latency_ms INTEGER NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for webhook_deliveries
+CREATE INDEX idx_webhook_deliveries_bot_id ON webhook_deliveries(bot_id);
+CREATE INDEX idx_webhook_deliveries_webhook_event_id ON webhook_deliveries(webhook_event_id) WHERE webhook_event_id IS NOT NULL;
+
 -- Create messages table for tracking sent messages
 CREATE TABLE IF NOT EXISTS messages (
     id SERIAL PRIMARY KEY,
     bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
-    message_type VARCHAR(50) NOT NULL, -- push, broadcast, multicast, narrowcast, reply
+    message_type VARCHAR(50) NOT NULL, -- push, broadcast, multicast, narrowcast, reply, pnp
     recipient_type VARCHAR(50), -- user, group, room, all, multiple
-    recipient_id TEXT, -- user_id, group_id, room_id, or comma-separated list for multicast
+    recipient_id TEXT, -- user_id, group_id or room_id for push and reply. The recipients of every message are in message_deliveries
     content JSONB NOT NULL, -- Store the actual message content as JSON
-    retry_key UUID,
+    retry_key UUID, -- X-Line-Retry-Key of the API call. Retry keys are scoped to the bot and kept for 24 hours
+    request_id VARCHAR(255), -- X-Line-Request-Id of the API call, returned in x-line-accepted-request-id for retried requests
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
 -- Create indexes for messages
 CREATE INDEX idx_messages_bot_id ON messages(bot_id);
-CREATE INDEX idx_messages_retry_key ON messages(retry_key) WHERE retry_key IS NOT NULL;
-CREATE INDEX idx_messages_created_at ON messages(created_at);
\ No newline at end of file
+CREATE UNIQUE INDEX idx_messages_retry_key ON messages(bot_id, retry_key) WHERE retry_key IS NOT NULL;
+CREATE INDEX idx_messages_created_at ON messages(created_at);
+
+-- Create message_deliveries table for the users, groups and rooms each message was delivered to
+CREATE TABLE IF NOT EXISTS message_deliveries (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    recipient_type VARCHAR(10) NOT NULL CHECK (recipient_type IN ('user', 'group', 'room')),
+    recipient_id VARCHAR(255) NOT NULL, -- user_id, group_id or room_id
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for listing the recipients of a message
+CREATE INDEX idx_message_deliveries_message_id ON message_deliveries(message_id);
+
+-- Create message_aggregation_units table for the custom aggregation units messages were sent with
+CREATE TABLE IF NOT EXISTS message_aggregation_units (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    name VARCHAR(30) NOT NULL, -- Case-sensitive
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for counting the aggregation units used by a bot in a month
+CREATE INDEX idx_message_aggregation_units_bot_id_created_at ON message_aggregation_units(bot_id, created_at);
+
+-- Create sent_messages table for the ID and quote token of each message object sent by bots
+CREATE TABLE IF NOT EXISTS sent_messages (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE, -- The API call which sent the message object
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL, -- Index of the message object in the request
+    line_message_id VARCHAR(255) UNIQUE NOT NULL,
+    quote_token VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for listing the message objects of a message
+CREATE INDEX idx_sent_messages_message_id ON sent_messages(message_id, position);
+
+-- Create narrowcast_jobs table for narrowcast messages which are sent in the background
+CREATE TABLE IF NOT EXISTS narrowcast_jobs (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    request_id VARCHAR(255) UNIQUE NOT NULL, -- Returned in the X-Line-Request-Id header and used to get the progress
+    filter JSONB, -- The filter object of the request. NULL to send to every follower
+    limit_max INTEGER,
+    up_to_remaining_quota BOOLEAN NOT NULL DEFAULT false,
+    phase VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (phase IN ('waiting', 'sending', 'succeeded', 'failed')),
+    target_count INTEGER, -- Set once the recipients have been selected
+    success_count INTEGER,
+    failure_count INTEGER,
+    error_code INTEGER,
+    failed_description TEXT,
+    lease_until TIMESTAMP WITH TIME ZONE, -- Jobs left in the sending phase after this time are picked up again
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index for picking up waiting narrowcast jobs
+CREATE INDEX idx_narrowcast_jobs_phase ON narrowcast_jobs(phase, id);
+
+-- Create conversation_messages table for the chat timeline between a bot and each user, group or room
+CREATE TABLE IF NOT EXISTS conversation_messages (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    chat_type VARCHAR(10) NOT NULL CHECK (chat_type IN ('user', 'group', 'room')),
+    chat_id VARCHAR(255) NOT NULL, -- user_id, group_id or room_id
+    sender VARCHAR(10) NOT NULL CHECK (sender IN ('bot', 'user')),
+    message_id INTEGER REFERENCES messages(id) ON DELETE CASCADE, -- The API call which sent the message. NULL for messages sent by users
+    content JSONB NOT NULL, -- A single message object
+    read_at TIMESTAMP WITH TIME ZONE, -- When the bot marked the message sent by the user as read. NULL if unread or sent by the bot
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for reading a conversation in order
+CREATE INDEX idx_conversation_messages_bot_id_chat_id ON conversation_messages(bot_id, chat_id, id);
+
+-- Create loading_animations table for the loading indicator the bot shows in the chat with each user
+CREATE TABLE IF NOT EXISTS loading_animations (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    chat_id VARCHAR(255) NOT NULL, -- user_id of the chat
+    loading_seconds INTEGER NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- The indicator disappears at this time unless the bot sends a message earlier
+    UNIQUE(bot_id, chat_id)
+);
+
+-- Create api_calls table for logging every Messaging API request and its response
+CREATE TABLE IF NOT EXISTS api_calls (
+    id SERIAL PRIMARY KEY,
+    request_id VARCHAR(255) UNIQUE NOT NULL, -- X-Line-Request-Id returned in the response
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL, -- Including the query string
+    request_headers JSONB NOT NULL,
+    request_body TEXT, -- NULL for binary bodies
+    status_code INTEGER NOT NULL,
+    response_headers JSONB NOT NULL,
+    response_body TEXT, -- NULL for binary bodies
+    latency_ms INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create blobs table for the binary content of messages when it isn't stored in a directory
+CREATE TABLE IF NOT EXISTS blobs (
+    key VARCHAR(255) PRIMARY KEY,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create message_contents table for the content of image, video, audio and file messages sent by users
+CREATE TABLE IF NOT EXISTS message_contents (
+    id SERIAL PRIMARY KEY,
+    bot_id INTEGER NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    message_id VARCHAR(255) NOT NULL, -- ID of the message object in the message event
+    message_type VARCHAR(10) NOT NULL CHECK (message_type IN ('image', 'video', 'audio', 'file')),
+    content_type VARCHAR(255) NOT NULL, -- Content-Type the content is served with
+    blob_key VARCHAR(255) NOT NULL,
+    preview_blob_key VARCHAR(255), -- NULL for audio and file messages, which have no preview image
+    size BIGINT NOT NULL,
+    transcoding_completed_at TIMESTAMP WITH TIME ZONE NOT NULL, -- The content is processing until this time
+    transcoding_result VARCHAR(10) NOT NULL CHECK (transcoding_result IN ('succeeded', 'failed')), -- Status after the content is processed
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(bot_id, message_id)
+);
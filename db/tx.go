@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// InTx runs fn with queries which run in a transaction, and commits the transaction if fn succeeds.
+// Queries which already run in a transaction (e.g. in tests) run fn in a savepoint.
+func InTx(ctx context.Context, q Querier, fn func(Querier) error) error {
+	queries, ok := q.(*Queries)
+	if !ok {
+		return errors.New("queries can't begin a transaction")
+	}
+	beginner, ok := queries.db.(interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+	})
+	if !ok {
+		return errors.New("queries can't begin a transaction")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
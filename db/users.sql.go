@@ -7,15 +7,42 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const blockBotFollower = `-- name: BlockBotFollower :one
+UPDATE bot_followers
+SET blocked_at = CURRENT_TIMESTAMP
+WHERE bot_id = $1 AND user_id = $2
+RETURNING id, bot_id, user_id, followed_at, blocked_at
+`
+
+type BlockBotFollowerParams struct {
+	BotID  int32 `db:"bot_id" json:"bot_id"`
+	UserID int32 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) BlockBotFollower(ctx context.Context, arg BlockBotFollowerParams) (BotFollower, error) {
+	row := q.db.QueryRow(ctx, blockBotFollower, arg.BotID, arg.UserID)
+	var i BotFollower
+	err := row.Scan(
+		&i.ID,
+		&i.BotID,
+		&i.UserID,
+		&i.FollowedAt,
+		&i.BlockedAt,
+	)
+	return i, err
+}
+
 const createBotFollower = `-- name: CreateBotFollower :one
 INSERT INTO bot_followers (
     bot_id,
     user_id
 ) VALUES (
     $1, $2
-) RETURNING id, bot_id, user_id, followed_at
+) RETURNING id, bot_id, user_id, followed_at, blocked_at
 `
 
 type CreateBotFollowerParams struct {
@@ -31,6 +58,7 @@ func (q *Queries) CreateBotFollower(ctx context.Context, arg CreateBotFollowerPa
 		&i.BotID,
 		&i.UserID,
 		&i.FollowedAt,
+		&i.BlockedAt,
 	)
 	return i, err
 }
@@ -49,7 +77,7 @@ INSERT INTO users (
     language
 ) VALUES (
     $1, $2, $3, $4, $5
-) RETURNING id, user_id, display_name, picture_url, status_message, language, created_at, updated_at
+) RETURNING id, user_id, display_name, picture_url, status_message, language, gender, age, app_type, area, created_at, updated_at
 `
 
 type CreateUserParams struct {
@@ -76,6 +104,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PictureUrl,
 		&i.StatusMessage,
 		&i.Language,
+		&i.Gender,
+		&i.Age,
+		&i.AppType,
+		&i.Area,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -90,8 +122,132 @@ type CreateUsersParams struct {
 	Language      *string `db:"language" json:"language"`
 }
 
+const getAllBotFollowerUserIDs = `-- name: GetAllBotFollowerUserIDs :many
+SELECT u.user_id FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
+ORDER BY bf.id
+`
+
+func (q *Queries) GetAllBotFollowerUserIDs(ctx context.Context, botID int32) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAllBotFollowerUserIDs, botID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlockedUserIDs = `-- name: GetBlockedUserIDs :many
+SELECT u.user_id FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NOT NULL AND u.user_id = ANY($2::text[])
+`
+
+type GetBlockedUserIDsParams struct {
+	BotID   int32    `db:"bot_id" json:"bot_id"`
+	UserIds []string `db:"user_ids" json:"user_ids"`
+}
+
+func (q *Queries) GetBlockedUserIDs(ctx context.Context, arg GetBlockedUserIDsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getBlockedUserIDs, arg.BotID, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBotFollower = `-- name: GetBotFollower :one
+SELECT id, bot_id, user_id, followed_at, blocked_at FROM bot_followers
+WHERE bot_id = $1 AND user_id = $2
+`
+
+type GetBotFollowerParams struct {
+	BotID  int32 `db:"bot_id" json:"bot_id"`
+	UserID int32 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) GetBotFollower(ctx context.Context, arg GetBotFollowerParams) (BotFollower, error) {
+	row := q.db.QueryRow(ctx, getBotFollower, arg.BotID, arg.UserID)
+	var i BotFollower
+	err := row.Scan(
+		&i.ID,
+		&i.BotID,
+		&i.UserID,
+		&i.FollowedAt,
+		&i.BlockedAt,
+	)
+	return i, err
+}
+
+const getBotFollowerAttributes = `-- name: GetBotFollowerAttributes :many
+SELECT u.user_id, u.gender, u.age, u.app_type, u.area, bf.followed_at FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
+ORDER BY bf.id
+`
+
+type GetBotFollowerAttributesRow struct {
+	UserID     string             `db:"user_id" json:"user_id"`
+	Gender     *string            `db:"gender" json:"gender"`
+	Age        *int32             `db:"age" json:"age"`
+	AppType    *string            `db:"app_type" json:"app_type"`
+	Area       *string            `db:"area" json:"area"`
+	FollowedAt pgtype.Timestamptz `db:"followed_at" json:"followed_at"`
+}
+
+func (q *Queries) GetBotFollowerAttributes(ctx context.Context, botID int32) ([]GetBotFollowerAttributesRow, error) {
+	rows, err := q.db.Query(ctx, getBotFollowerAttributes, botID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBotFollowerAttributesRow{}
+	for rows.Next() {
+		var i GetBotFollowerAttributesRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Gender,
+			&i.Age,
+			&i.AppType,
+			&i.Area,
+			&i.FollowedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getBotFollowerCount = `-- name: GetBotFollowerCount :one
-SELECT COUNT(*) FROM bot_followers WHERE bot_id = $1
+SELECT COUNT(*) FROM bot_followers WHERE bot_id = $1 AND blocked_at IS NULL
 `
 
 func (q *Queries) GetBotFollowerCount(ctx context.Context, botID int32) (int64, error) {
@@ -102,9 +258,9 @@ func (q *Queries) GetBotFollowerCount(ctx context.Context, botID int32) (int64,
 }
 
 const getBotFollowerUser = `-- name: GetBotFollowerUser :one
-SELECT u.id, u.user_id, u.display_name, u.picture_url, u.status_message, u.language, u.created_at, u.updated_at FROM users u
+SELECT u.id, u.user_id, u.display_name, u.picture_url, u.status_message, u.language, u.gender, u.age, u.app_type, u.area, u.created_at, u.updated_at FROM users u
 INNER JOIN bot_followers bf ON u.id = bf.user_id
-WHERE bf.bot_id = $1 AND u.user_id = $2
+WHERE bf.bot_id = $1 AND u.user_id = $2 AND bf.blocked_at IS NULL
 `
 
 type GetBotFollowerUserParams struct {
@@ -122,6 +278,10 @@ func (q *Queries) GetBotFollowerUser(ctx context.Context, arg GetBotFollowerUser
 		&i.PictureUrl,
 		&i.StatusMessage,
 		&i.Language,
+		&i.Gender,
+		&i.Age,
+		&i.AppType,
+		&i.Area,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -131,7 +291,7 @@ func (q *Queries) GetBotFollowerUser(ctx context.Context, arg GetBotFollowerUser
 const getBotFollowerUserIDs = `-- name: GetBotFollowerUserIDs :many
 SELECT u.user_id FROM users u
 INNER JOIN bot_followers bf ON u.id = bf.user_id
-WHERE bf.bot_id = $1
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
 ORDER BY bf.followed_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -163,9 +323,9 @@ func (q *Queries) GetBotFollowerUserIDs(ctx context.Context, arg GetBotFollowerU
 }
 
 const getBotFollowers = `-- name: GetBotFollowers :many
-SELECT u.id, u.user_id, u.display_name, u.picture_url, u.status_message, u.language, u.created_at, u.updated_at FROM users u
+SELECT u.id, u.user_id, u.display_name, u.picture_url, u.status_message, u.language, u.gender, u.age, u.app_type, u.area, u.created_at, u.updated_at FROM users u
 INNER JOIN bot_followers bf ON u.id = bf.user_id
-WHERE bf.bot_id = $1
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL
 ORDER BY bf.followed_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -192,6 +352,10 @@ func (q *Queries) GetBotFollowers(ctx context.Context, arg GetBotFollowersParams
 			&i.PictureUrl,
 			&i.StatusMessage,
 			&i.Language,
+			&i.Gender,
+			&i.Age,
+			&i.AppType,
+			&i.Area,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -205,8 +369,39 @@ func (q *Queries) GetBotFollowers(ctx context.Context, arg GetBotFollowersParams
 	return items, nil
 }
 
+const getFollowingUserIDs = `-- name: GetFollowingUserIDs :many
+SELECT u.user_id FROM users u
+INNER JOIN bot_followers bf ON u.id = bf.user_id
+WHERE bf.bot_id = $1 AND bf.blocked_at IS NULL AND u.user_id = ANY($2::text[])
+`
+
+type GetFollowingUserIDsParams struct {
+	BotID   int32    `db:"bot_id" json:"bot_id"`
+	UserIds []string `db:"user_ids" json:"user_ids"`
+}
+
+func (q *Queries) GetFollowingUserIDs(ctx context.Context, arg GetFollowingUserIDsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getFollowingUserIDs, arg.BotID, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, user_id, display_name, picture_url, status_message, language, created_at, updated_at FROM users
+SELECT id, user_id, display_name, picture_url, status_message, language, gender, age, app_type, area, created_at, updated_at FROM users
 WHERE user_id = $1
 `
 
@@ -220,6 +415,10 @@ func (q *Queries) GetUser(ctx context.Context, userID string) (User, error) {
 		&i.PictureUrl,
 		&i.StatusMessage,
 		&i.Language,
+		&i.Gender,
+		&i.Age,
+		&i.AppType,
+		&i.Area,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -227,7 +426,7 @@ func (q *Queries) GetUser(ctx context.Context, userID string) (User, error) {
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, user_id, display_name, picture_url, status_message, language, created_at, updated_at FROM users WHERE id = $1
+SELECT id, user_id, display_name, picture_url, status_message, language, gender, age, app_type, area, created_at, updated_at FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
@@ -240,6 +439,10 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 		&i.PictureUrl,
 		&i.StatusMessage,
 		&i.Language,
+		&i.Gender,
+		&i.Age,
+		&i.AppType,
+		&i.Area,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -247,7 +450,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 }
 
 const getUsersByUserIDs = `-- name: GetUsersByUserIDs :many
-SELECT id, user_id, display_name, picture_url, status_message, language, created_at, updated_at FROM users WHERE user_id = ANY($1::text[])
+SELECT id, user_id, display_name, picture_url, status_message, language, gender, age, app_type, area, created_at, updated_at FROM users WHERE user_id = ANY($1::text[])
 `
 
 func (q *Queries) GetUsersByUserIDs(ctx context.Context, dollar_1 []string) ([]User, error) {
@@ -266,6 +469,10 @@ func (q *Queries) GetUsersByUserIDs(ctx context.Context, dollar_1 []string) ([]U
 			&i.PictureUrl,
 			&i.StatusMessage,
 			&i.Language,
+			&i.Gender,
+			&i.Age,
+			&i.AppType,
+			&i.Area,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -283,7 +490,7 @@ const isBotFollower = `-- name: IsBotFollower :one
 SELECT EXISTS (
     SELECT 1 FROM bot_followers bf
     INNER JOIN users u ON u.id = bf.user_id
-    WHERE bf.bot_id = $1 AND u.user_id = $2
+    WHERE bf.bot_id = $1 AND u.user_id = $2 AND bf.blocked_at IS NULL
 )
 `
 
@@ -298,3 +505,81 @@ func (q *Queries) IsBotFollower(ctx context.Context, arg IsBotFollowerParams) (b
 	err := row.Scan(&exists)
 	return exists, err
 }
+
+const updateUserAttributes = `-- name: UpdateUserAttributes :one
+UPDATE users
+SET
+    gender = $1,
+    age = $2,
+    app_type = $3,
+    area = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $5
+RETURNING id, user_id, display_name, picture_url, status_message, language, gender, age, app_type, area, created_at, updated_at
+`
+
+type UpdateUserAttributesParams struct {
+	Gender  *string `db:"gender" json:"gender"`
+	Age     *int32  `db:"age" json:"age"`
+	AppType *string `db:"app_type" json:"app_type"`
+	Area    *string `db:"area" json:"area"`
+	UserID  string  `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) UpdateUserAttributes(ctx context.Context, arg UpdateUserAttributesParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserAttributes,
+		arg.Gender,
+		arg.Age,
+		arg.AppType,
+		arg.Area,
+		arg.UserID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.DisplayName,
+		&i.PictureUrl,
+		&i.StatusMessage,
+		&i.Language,
+		&i.Gender,
+		&i.Age,
+		&i.AppType,
+		&i.Area,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertBotFollower = `-- name: UpsertBotFollower :one
+INSERT INTO bot_followers (
+    bot_id,
+    user_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (bot_id, user_id)
+DO UPDATE SET
+    blocked_at = NULL,
+    followed_at = CURRENT_TIMESTAMP
+RETURNING id, bot_id, user_id, followed_at, blocked_at
+`
+
+type UpsertBotFollowerParams struct {
+	BotID  int32 `db:"bot_id" json:"bot_id"`
+	UserID int32 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) UpsertBotFollower(ctx context.Context, arg UpsertBotFollowerParams) (BotFollower, error) {
+	row := q.db.QueryRow(ctx, upsertBotFollower, arg.BotID, arg.UserID)
+	var i BotFollower
+	err := row.Scan(
+		&i.ID,
+		&i.BotID,
+		&i.UserID,
+		&i.FollowedAt,
+		&i.BlockedAt,
+	)
+	return i, err
+}
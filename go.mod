@@ -4,6 +4,7 @@ go 1.24.4
 
 require (
 	github.com/DATA-DOG/go-txdb v0.2.1
+	github.com/brianvoe/gofakeit/v7 v7.4.0
 	github.com/cockroachdb/errors v1.12.0
 	github.com/docker/docker v28.3.3+incompatible
 	github.com/go-chi/chi/v5 v5.2.2
@@ -58,7 +59,6 @@ require (
 	github.com/bombsimon/wsl/v5 v5.1.1 // indirect
 	github.com/breml/bidichk v0.3.3 // indirect
 	github.com/breml/errchkjson v0.4.1 // indirect
-	github.com/brianvoe/gofakeit/v7 v7.4.0 // indirect
 	github.com/butuzov/ireturn v0.4.0 // indirect
 	github.com/butuzov/mirror v1.3.0 // indirect
 	github.com/catenacyber/perfsprint v0.9.1 // indirect
@@ -0,0 +1,100 @@
+// Package blobstore keeps the binary content of messages sent by emulated users, such as images and videos.
+// The content is stored in a directory on the local filesystem, or in the blobs table when no directory is configured.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+// ErrNotFound is returned when no content is stored with the key
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps binary content by key
+type Store interface {
+	// Put stores the content with the key, replacing the content already stored with it
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the content stored with the key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// NewFileStore creates a store which keeps each content in a file under the directory.
+// The directory is created if it doesn't exist.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+type fileStore struct {
+	dir string
+}
+
+func (s *fileStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	return nil
+}
+
+func (s *fileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// path returns the file the content with the key is kept in
+// Keys are file names, so that content can't be written outside of the directory.
+func (s *fileStore) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// NewDBStore creates a store which keeps content in the blobs table
+func NewDBStore(q db.Querier) Store {
+	return &dbStore{db: q}
+}
+
+type dbStore struct {
+	db db.Querier
+}
+
+func (s *dbStore) Put(ctx context.Context, key string, data []byte) error {
+	if err := s.db.PutBlob(ctx, db.PutBlobParams{Key: key, Data: data}); err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return nil
+}
+
+func (s *dbStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.db.GetBlob(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,43 @@
+package blobstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir() + "/blobs")
+	require.NoError(t, err)
+
+	t.Run("returns the stored content", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "100001", []byte("first")))
+		data, err := store.Get(ctx, "100001")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), data)
+	})
+
+	t.Run("replaces the content stored with the same key", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "100002", []byte("first")))
+		require.NoError(t, store.Put(ctx, "100002", []byte("second")))
+		data, err := store.Get(ctx, "100002")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("second"), data)
+	})
+
+	t.Run("returns ErrNotFound for an unknown key", func(t *testing.T) {
+		_, err := store.Get(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects keys outside of the directory", func(t *testing.T) {
+		for _, key := range []string{"", ".", "..", "../escaped", "nested/key"} {
+			assert.Error(t, store.Put(ctx, key, []byte("data")), key)
+		}
+	})
+}
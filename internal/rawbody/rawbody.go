@@ -0,0 +1,46 @@
+// Package rawbody keeps the raw request body available to handlers.
+// The generated messaging API types only decode the fields common to every type of an object,
+// such as the type of a message, a demographic filter or an action,
+// so handlers which need the full objects (e.g. to validate them) read them from the raw body.
+package rawbody
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+type bodyContextKey struct {
+}
+
+// Middleware reads the request body, stores it in the request context and restores it for the next handler.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		next.ServeHTTP(w, r.WithContext(SetBody(r.Context(), body)))
+	})
+}
+
+// SetBody sets the raw request body in the context
+// It shouldn't be used outside of this package except for testing
+func SetBody(ctx context.Context, body []byte) context.Context {
+	return context.WithValue(ctx, bodyContextKey{}, body)
+}
+
+// GetBody returns the raw request body, or nil if it isn't available
+func GetBody(ctx context.Context) []byte {
+	body, _ := ctx.Value(bodyContextKey{}).([]byte)
+	return body
+}
@@ -0,0 +1,46 @@
+package rawbody_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("keeps the body for the context and the next handler", func(t *testing.T) {
+		const body = `{"to":"U123","messages":[{"type":"text","text":"Hello"}]}`
+
+		handler := rawbody.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, body, string(rawbody.GetBody(r.Context())))
+			read, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(read))
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/v2/bot/message/push", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("returns nil without body", func(t *testing.T) {
+		handler := rawbody.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Nil(t, rawbody.GetBody(r.Context()))
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v2/bot/info", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,131 @@
+// Package requestid issues the request ID returned in the X-Line-Request-Id header of every Messaging API response.
+// Each request and its response are recorded in the api_calls table, so that they can be looked up by the request ID.
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+// HeaderName is the response header the request ID is returned in
+const HeaderName = "X-Line-Request-Id"
+
+// maxRecordedBodySize is how much of the request and response bodies is recorded. Longer bodies are truncated.
+const maxRecordedBodySize = 64 * 1024
+
+type requestIDContextKey struct {
+}
+
+// Middleware issues a request ID, returns it in the X-Line-Request-Id header and records the API call.
+// The request body is recorded from the one kept by rawbody.Middleware, which must run before this middleware.
+func Middleware(q db.Querier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := lineid.NewRequestID()
+			w.Header().Set(HeaderName, requestID)
+
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r.WithContext(SetRequestID(r.Context(), requestID)))
+
+			call := db.CreateApiCallParams{
+				RequestID:      requestID,
+				Method:         r.Method,
+				Path:           r.URL.RequestURI(),
+				RequestHeaders: marshalHeader(r.Header),
+				RequestBody:    textBody(rawbody.GetBody(r.Context())),
+				StatusCode:     int32(rec.statusCode),
+				// The headers are read after the handler returns, so they include the ones set by the handler
+				ResponseHeaders: marshalHeader(w.Header()),
+				ResponseBody:    textBody(rec.body.Bytes()),
+				LatencyMs:       int32(time.Since(start).Milliseconds()),
+			}
+			// The request has already been answered, so a failure to record it is only logged
+			if _, err := q.CreateApiCall(context.WithoutCancel(r.Context()), call); err != nil {
+				slog.ErrorContext(r.Context(), "Failed to record API call", slog.String("requestId", requestID), slog.Any("error", err))
+			}
+		})
+	}
+}
+
+// SetRequestID sets the request ID in the context
+// It shouldn't be used outside of this package except for testing
+func SetRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// GetRequestID returns the request ID of the API call, or an empty string if it isn't available
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// responseRecorder keeps the status code and the beginning of the body written by the next handler
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	// One byte more than recorded is kept so that textBody knows the body is truncated
+	if remaining := maxRecordedBodySize + 1 - r.body.Len(); remaining > 0 {
+		r.body.Write(b[:min(len(b), remaining)])
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// marshalHeader converts the headers to a JSON object, leaving out the channel access token
+func marshalHeader(header http.Header) []byte {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		if key == "Authorization" {
+			continue
+		}
+		headers[key] = header.Get(key)
+	}
+	b, err := json.Marshal(headers)
+	if err != nil {
+		return []byte("{}")
+	}
+	return b
+}
+
+// textBody returns the body as a string, or nil if it is empty or binary such as image content.
+// Bodies longer than maxRecordedBodySize are truncated.
+func textBody(body []byte) *string {
+	if len(body) > maxRecordedBodySize {
+		body = body[:maxRecordedBodySize]
+		// Drop the character cut off at the end
+		for range utf8.UTFMax - 1 {
+			if r, size := utf8.DecodeLastRune(body); r != utf8.RuneError || size != 1 {
+				break
+			}
+			body = body[:len(body)-1]
+		}
+	}
+	if len(body) == 0 || !utf8.Valid(body) || bytes.IndexByte(body, 0) >= 0 {
+		return nil
+	}
+	s := string(body)
+	return &s
+}
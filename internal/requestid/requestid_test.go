@@ -0,0 +1,114 @@
+package requestid_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+	"github.com/zero-color/line-messaging-api-emulator/internal/requestid"
+)
+
+func TestMiddleware(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	ctx := context.Background()
+
+	t.Run("returns the request ID and records the API call", func(t *testing.T) {
+		const body = `{"to":"U123","messages":[{"type":"text","text":"Hello"}]}`
+
+		var handledRequestID string
+		handler := rawbody.Middleware(requestid.Middleware(dbClient)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handledRequestID = requestid.GetRequestID(r.Context())
+			read, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(read))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"sentMessages":[]}`))
+		})))
+
+		req := httptest.NewRequest(http.MethodPost, "/v2/bot/message/push?foo=bar", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		requestID := rec.Header().Get(requestid.HeaderName)
+		require.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, handledRequestID)
+
+		call, err := dbClient.GetApiCallByRequestID(ctx, requestID)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, call.Method)
+		assert.Equal(t, "/v2/bot/message/push?foo=bar", call.Path)
+		assert.Equal(t, body, lo.FromPtr(call.RequestBody))
+		assert.Equal(t, int32(http.StatusOK), call.StatusCode)
+		assert.Equal(t, `{"sentMessages":[]}`, lo.FromPtr(call.ResponseBody))
+
+		var requestHeaders, responseHeaders map[string]string
+		require.NoError(t, json.Unmarshal(call.RequestHeaders, &requestHeaders))
+		assert.Equal(t, "application/json", requestHeaders["Content-Type"])
+		assert.NotContains(t, requestHeaders, "Authorization")
+		require.NoError(t, json.Unmarshal(call.ResponseHeaders, &responseHeaders))
+		assert.Equal(t, requestID, responseHeaders[requestid.HeaderName])
+	})
+
+	t.Run("records errors returned before the handler", func(t *testing.T) {
+		handler := requestid.Middleware(dbClient)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Unauthorized: missing authorization header", http.StatusUnauthorized)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v2/bot/info", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		requestID := rec.Header().Get(requestid.HeaderName)
+		require.NotEmpty(t, requestID)
+		call, err := dbClient.GetApiCallByRequestID(ctx, requestID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(http.StatusUnauthorized), call.StatusCode)
+		assert.Nil(t, call.RequestBody)
+	})
+
+	t.Run("doesn't record binary bodies", func(t *testing.T) {
+		handler := requestid.Middleware(dbClient)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89, 'P', 'N', 'G', 0x00, 0xff})
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v2/bot/message/1/content", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		call, err := dbClient.GetApiCallByRequestID(ctx, rec.Header().Get(requestid.HeaderName))
+		require.NoError(t, err)
+		assert.Equal(t, int32(http.StatusOK), call.StatusCode)
+		assert.Nil(t, call.ResponseBody)
+	})
+
+	t.Run("truncates long bodies", func(t *testing.T) {
+		// The multibyte character is cut off at the limit of 64 KiB
+		body := strings.Repeat("a", 64*1024-1) + "あ" + strings.Repeat("a", 1024)
+		handler := rawbody.Middleware(requestid.Middleware(dbClient)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		})))
+
+		req := httptest.NewRequest(http.MethodPost, "/v2/bot/message/push", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, body, rec.Body.String())
+
+		call, err := dbClient.GetApiCallByRequestID(ctx, rec.Header().Get(requestid.HeaderName))
+		require.NoError(t, err)
+		assert.Equal(t, body[:64*1024-1], lo.FromPtr(call.RequestBody))
+		assert.Equal(t, body[:64*1024-1], lo.FromPtr(call.ResponseBody))
+	})
+}
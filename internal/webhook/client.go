@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseBodyExcerpt is the maximum number of bytes of the bot server's response body kept in a Result.
+const maxResponseBodyExcerpt = 1024
+
+// Client sends webhook requests to bot servers.
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient creates a new webhook client.
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Result is the outcome of a webhook request.
+type Result struct {
+	// RequestHeader is the header sent to the bot server.
+	RequestHeader http.Header
+	// StatusCode is 0 if the request didn't reach the bot server.
+	StatusCode int
+	Status     string
+	// ResponseBody is the beginning of the body returned by the bot server.
+	ResponseBody string
+	Latency      time.Duration
+	// Err is set when the request couldn't be sent or no response was received (e.g. timeout).
+	Err error
+}
+
+// Success reports whether the bot server accepted the webhook.
+func (r *Result) Success() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// FailureReason describes why the webhook was not accepted. It is empty on success.
+func (r *Result) FailureReason() string {
+	switch {
+	case r.Err != nil:
+		return r.Err.Error()
+	case !r.Success():
+		return fmt.Sprintf("Bot server responded with %s", r.Status)
+	default:
+		return ""
+	}
+}
+
+// Send posts the body to the webhook endpoint, signed with the channel secret.
+func (c *Client) Send(ctx context.Context, endpoint, channelSecret string, body []byte) *Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return &Result{Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Line-Signature", Sign(channelSecret, body))
+	req.Header.Set("User-Agent", "LineBotWebhook/2.0")
+
+	result := &Result{RequestHeader: req.Header.Clone()}
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		result.Latency = time.Since(start)
+		result.Err = fmt.Errorf("failed to connect to webhook: %w", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyExcerpt))
+	result.Latency = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	result.Status = resp.Status
+	result.ResponseBody = string(excerpt)
+	return result
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+// RecordDelivery stores a webhook request and the bot server's response in the delivery log.
+// webhookEventID is nil for requests which don't carry a queued event, such as test requests.
+func RecordDelivery(ctx context.Context, q db.Querier, botID int32, webhookEventID *string, endpoint string, body []byte, result *Result) error {
+	headers := make(map[string]string, len(result.RequestHeader))
+	for key := range result.RequestHeader {
+		headers[key] = result.RequestHeader.Get(key)
+	}
+	requestHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request headers: %w", err)
+	}
+
+	params := db.CreateWebhookDeliveryParams{
+		BotID:          botID,
+		WebhookEventID: webhookEventID,
+		Endpoint:       endpoint,
+		RequestHeaders: requestHeaders,
+		RequestBody:    string(body),
+		LatencyMs:      int32(result.Latency.Milliseconds()),
+	}
+	if result.StatusCode != 0 {
+		params.StatusCode = lo.ToPtr(int32(result.StatusCode))
+		params.ResponseBody = &result.ResponseBody
+	}
+	if reason := result.FailureReason(); reason != "" {
+		params.Error = &reason
+	}
+
+	if _, err := q.CreateWebhookDelivery(ctx, params); err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 10
+	defaultMaxAttempts  = 5
+	maxBackoff          = 5 * time.Minute
+	// leaseTimeout must be longer than the client timeout so that an event is
+	// not picked up by another worker while it is being delivered.
+	leaseTimeout = 30 * time.Second
+)
+
+// Enqueue stores the events so that they are delivered to the bot's webhook by a Dispatcher.
+// Reply tokens included in the events are issued to the bot at issuedAt, which is the time of the clock the events happened on.
+// The lifetime of a reply token starts when the event is queued rather than when it is first delivered,
+// so an event redelivered after the TTL of reply tokens carries a token which has already expired.
+// The events are due right away by the clock of the Dispatcher.
+func Enqueue(ctx context.Context, q db.Querier, botID int32, issuedAt time.Time, events ...Event) error {
+	now := time.Now()
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook event: %w", err)
+		}
+		if event.ReplyToken != "" && event.Source != nil {
+			if _, err := q.CreateReplyToken(ctx, db.CreateReplyTokenParams{
+				BotID:          botID,
+				Token:          event.ReplyToken,
+				WebhookEventID: event.WebhookEventID,
+				SourceType:     event.Source.Type,
+				SourceID:       event.Source.ChatID(),
+				IssuedAt:       pgtype.Timestamptz{Time: issuedAt, Valid: true},
+			}); err != nil {
+				return fmt.Errorf("failed to issue reply token: %w", err)
+			}
+		}
+		if _, err := q.CreateWebhookEvent(ctx, db.CreateWebhookEventParams{
+			BotID:          botID,
+			WebhookEventID: event.WebhookEventID,
+			Payload:        payload,
+			NextAttemptAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to enqueue webhook event: %w", err)
+		}
+	}
+	return nil
+}
+
+// Dispatcher delivers queued webhook events to bot servers.
+// Failed deliveries are retried with backoff when the bot has webhook redelivery enabled,
+// and retried events are sent with deliveryContext.isRedelivery set to true.
+type Dispatcher struct {
+	db           db.Querier
+	client       *Client
+	pollInterval time.Duration
+	batchSize    int32
+	maxAttempts  int32
+	backoff      func(attempt int32) time.Duration
+}
+
+// DispatcherOption configures a Dispatcher.
+type DispatcherOption func(*Dispatcher)
+
+// WithPollInterval sets how often idle workers check the queue for due events.
+func WithPollInterval(interval time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.pollInterval = interval
+	}
+}
+
+// WithMaxAttempts sets how many times an event is sent before it is marked as failed.
+func WithMaxAttempts(maxAttempts int32) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoff sets the delay before retrying an event which failed on the given attempt.
+func WithBackoff(backoff func(attempt int32) time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.backoff = backoff
+	}
+}
+
+// NewDispatcher creates a new webhook dispatcher.
+func NewDispatcher(q db.Querier, client *Client, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		db:           q,
+		client:       client,
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		maxAttempts:  defaultMaxAttempts,
+		backoff:      exponentialBackoff,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// exponentialBackoff waits 1s, 2s, 4s, ... up to 5 minutes between attempts.
+func exponentialBackoff(attempt int32) time.Duration {
+	if attempt >= 10 {
+		return maxBackoff
+	}
+	return time.Second << (attempt - 1)
+}
+
+// Run starts the given number of workers and blocks until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		processed, err := d.ProcessPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to process webhook events", slog.Any("error", err))
+		}
+		if processed > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.pollInterval):
+		}
+	}
+}
+
+// ProcessPending delivers the events which are due and returns how many events were processed.
+// An event which fails to be delivered doesn't stop the rest of the batch; the errors are returned together.
+func (d *Dispatcher) ProcessPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	events, err := d.db.ClaimWebhookEvents(ctx, db.ClaimWebhookEventsParams{
+		LeaseUntil: pgtype.Timestamptz{Time: now.Add(leaseTimeout), Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		BatchSize:  d.batchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook events: %w", err)
+	}
+
+	var errs []error
+	for _, event := range events {
+		if err := d.deliver(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "Failed to deliver webhook event", slog.String("webhookEventId", event.WebhookEventID), slog.Any("error", err))
+			errs = append(errs, err)
+		}
+	}
+	return len(events), errors.Join(errs...)
+}
+
+// deliver sends a claimed event and records the result.
+func (d *Dispatcher) deliver(ctx context.Context, event db.WebhookEvent) error {
+	bot, err := d.db.GetBot(ctx, event.BotID)
+	if err != nil {
+		return fmt.Errorf("failed to get bot: %w", err)
+	}
+
+	webhook, err := d.db.GetWebhook(ctx, event.BotID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return d.fail(ctx, event, "No webhook configured")
+		}
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if !webhook.Active || webhook.Endpoint == "" {
+		return d.fail(ctx, event, "Webhook is not active")
+	}
+
+	var e Event
+	if err := json.Unmarshal(event.Payload, &e); err != nil {
+		return d.fail(ctx, event, fmt.Sprintf("Invalid webhook event: %v", err))
+	}
+	e.DeliveryContext.IsRedelivery = event.Attempts > 1
+
+	body, err := json.Marshal(Payload{
+		Destination: bot.UserID,
+		Events:      []Event{e},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	result := d.client.Send(ctx, webhook.Endpoint, bot.ChannelSecret, body)
+	if err := RecordDelivery(ctx, d.db, bot.ID, &event.WebhookEventID, webhook.Endpoint, body, result); err != nil {
+		return err
+	}
+
+	if result.Success() {
+		if err := d.db.MarkWebhookEventDelivered(ctx, event.ID); err != nil {
+			return fmt.Errorf("failed to mark webhook event as delivered: %w", err)
+		}
+		return nil
+	}
+
+	deliveryErr := result.FailureReason()
+	if !bot.WebhookRedelivery || event.Attempts >= d.maxAttempts {
+		return d.fail(ctx, event, deliveryErr)
+	}
+	if err := d.db.RetryWebhookEvent(ctx, db.RetryWebhookEventParams{
+		NextAttemptAt: pgtype.Timestamptz{Time: time.Now().Add(d.backoff(event.Attempts)), Valid: true},
+		LastError:     &deliveryErr,
+		ID:            event.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to schedule webhook event retry: %w", err)
+	}
+	return nil
+}
+
+func (d *Dispatcher) fail(ctx context.Context, event db.WebhookEvent, reason string) error {
+	if err := d.db.MarkWebhookEventFailed(ctx, db.MarkWebhookEventFailedParams{
+		LastError: &reason,
+		ID:        event.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to mark webhook event as failed: %w", err)
+	}
+	return nil
+}
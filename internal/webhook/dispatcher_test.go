@@ -0,0 +1,272 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+// setupBot creates a bot whose webhook endpoint responds with the given status codes in order.
+// The last status code is repeated once the list is exhausted.
+func setupBot(t *testing.T, q db.Querier, redelivery bool, statusCodes ...int) (db.Bot, chan webhook.Payload) {
+	t.Helper()
+	ctx := context.Background()
+
+	bot, err := q.CreateBot(ctx, db.CreateBotParams{
+		UserID:            "U" + lineid.NewReplyToken(),
+		BasicID:           "@" + lineid.NewReplyToken()[:8],
+		ChatMode:          "bot",
+		DisplayName:       "Test Bot",
+		MarkAsReadMode:    "manual",
+		ChannelSecret:     lineid.NewChannelSecret(),
+		WebhookRedelivery: redelivery,
+	})
+	require.NoError(t, err)
+
+	received := make(chan webhook.Payload, 10)
+	botServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.Sign(bot.ChannelSecret, body), r.Header.Get("X-Line-Signature"))
+
+		var payload webhook.Payload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		received <- payload
+
+		statusCode := statusCodes[0]
+		if len(statusCodes) > 1 {
+			statusCodes = statusCodes[1:]
+		}
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(botServer.Close)
+
+	err = q.UpsertWebhook(ctx, db.UpsertWebhookParams{
+		BotID:    bot.ID,
+		Endpoint: botServer.URL,
+	})
+	require.NoError(t, err)
+
+	return bot, received
+}
+
+func newTextEvent() webhook.Event {
+	return webhook.Event{
+		Type: webhook.EventTypeMessage,
+		Message: &webhook.Message{
+			ID:   lineid.NewMessageID(),
+			Type: "text",
+			Text: "Hello",
+		},
+		WebhookEventID: lineid.NewWebhookEventID(),
+		Timestamp:      time.Now().UnixMilli(),
+		Source: &webhook.Source{
+			Type:   webhook.SourceTypeUser,
+			UserID: "U_sender",
+		},
+		ReplyToken: lineid.NewReplyToken(),
+		Mode:       webhook.ModeActive,
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	noBackoff := webhook.WithBackoff(func(int32) time.Duration { return 0 })
+
+	t.Run("delivers queued event", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient())
+		bot, received := setupBot(t, q, false, http.StatusOK)
+
+		event := newTextEvent()
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, time.Now(), event))
+
+		processed, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+
+		payload := <-received
+		assert.Equal(t, bot.UserID, payload.Destination)
+		require.Len(t, payload.Events, 1)
+		assert.Equal(t, event.WebhookEventID, payload.Events[0].WebhookEventID)
+		assert.False(t, payload.Events[0].DeliveryContext.IsRedelivery)
+
+		stored, err := q.GetWebhookEventByWebhookEventID(ctx, event.WebhookEventID)
+		require.NoError(t, err)
+		assert.Equal(t, "delivered", stored.Status)
+		assert.Equal(t, int32(1), stored.Attempts)
+
+		// Delivered events are not sent again
+		processed, err = dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+
+	t.Run("does not retry when redelivery is disabled", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient(), noBackoff)
+		bot, received := setupBot(t, q, false, http.StatusInternalServerError)
+
+		event := newTextEvent()
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, time.Now(), event))
+
+		_, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		<-received
+
+		processed, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, processed)
+
+		stored, err := q.GetWebhookEventByWebhookEventID(ctx, event.WebhookEventID)
+		require.NoError(t, err)
+		assert.Equal(t, "failed", stored.Status)
+		assert.Equal(t, int32(1), stored.Attempts)
+		assert.Equal(t, "Bot server responded with 500 Internal Server Error", lo.FromPtr(stored.LastError))
+	})
+
+	t.Run("redelivers the reply token issued when the event was queued", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient(), noBackoff)
+		bot, received := setupBot(t, q, true, http.StatusInternalServerError, http.StatusOK)
+
+		event := newTextEvent()
+		issuedAt := time.Now().Add(-2 * time.Minute)
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, issuedAt, event))
+
+		_, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		<-received
+		_, err = dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		redelivered := <-received
+		assert.True(t, redelivered.Events[0].DeliveryContext.IsRedelivery)
+		assert.Equal(t, event.ReplyToken, redelivered.Events[0].ReplyToken)
+
+		// The token has expired by the redelivery, with a TTL of a minute from when the event was queued
+		_, err = q.UseReplyToken(ctx, db.UseReplyTokenParams{
+			UsedAt:      pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			Token:       event.ReplyToken,
+			BotID:       bot.ID,
+			IssuedAfter: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true},
+		})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("redelivers failed event when redelivery is enabled", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient(), noBackoff)
+		bot, received := setupBot(t, q, true, http.StatusInternalServerError, http.StatusOK)
+
+		event := newTextEvent()
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, time.Now(), event))
+
+		_, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		first := <-received
+		assert.False(t, first.Events[0].DeliveryContext.IsRedelivery)
+
+		stored, err := q.GetWebhookEventByWebhookEventID(ctx, event.WebhookEventID)
+		require.NoError(t, err)
+		assert.Equal(t, "pending", stored.Status)
+
+		_, err = dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		second := <-received
+		assert.True(t, second.Events[0].DeliveryContext.IsRedelivery)
+		assert.Equal(t, first.Events[0].WebhookEventID, second.Events[0].WebhookEventID)
+
+		stored, err = q.GetWebhookEventByWebhookEventID(ctx, event.WebhookEventID)
+		require.NoError(t, err)
+		assert.Equal(t, "delivered", stored.Status)
+		assert.Equal(t, int32(2), stored.Attempts)
+
+		// Both attempts are recorded in the delivery log
+		deliveries, err := q.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+			BotID:          bot.ID,
+			WebhookEventID: &event.WebhookEventID,
+			Limit:          10,
+		})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, int32(http.StatusOK), lo.FromPtr(deliveries[0].StatusCode))
+		assert.Nil(t, deliveries[0].Error)
+		assert.Equal(t, int32(http.StatusInternalServerError), lo.FromPtr(deliveries[1].StatusCode))
+		assert.NotNil(t, deliveries[1].Error)
+	})
+
+	t.Run("waits for backoff before redelivering", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient(), webhook.WithBackoff(func(int32) time.Duration { return time.Hour }))
+		bot, received := setupBot(t, q, true, http.StatusInternalServerError)
+
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, time.Now(), newTextEvent()))
+
+		_, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		<-received
+
+		processed, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient(), noBackoff, webhook.WithMaxAttempts(3))
+		bot, received := setupBot(t, q, true, http.StatusServiceUnavailable)
+
+		event := newTextEvent()
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, time.Now(), event))
+
+		for range 4 {
+			_, err := dispatcher.ProcessPending(ctx)
+			require.NoError(t, err)
+		}
+		assert.Len(t, received, 3)
+
+		stored, err := q.GetWebhookEventByWebhookEventID(ctx, event.WebhookEventID)
+		require.NoError(t, err)
+		assert.Equal(t, "failed", stored.Status)
+		assert.Equal(t, int32(3), stored.Attempts)
+	})
+
+	t.Run("marks event as failed when webhook is not configured", func(t *testing.T) {
+		q := db.NewTestDB(t)
+		dispatcher := webhook.NewDispatcher(q, webhook.NewClient())
+
+		bot, err := q.CreateBot(ctx, db.CreateBotParams{
+			UserID:         "U" + lineid.NewReplyToken(),
+			BasicID:        "@" + lineid.NewReplyToken()[:8],
+			ChatMode:       "bot",
+			DisplayName:    "Bot Without Webhook",
+			MarkAsReadMode: "manual",
+			ChannelSecret:  lineid.NewChannelSecret(),
+		})
+		require.NoError(t, err)
+
+		event := newTextEvent()
+		require.NoError(t, webhook.Enqueue(ctx, q, bot.ID, time.Now(), event))
+
+		_, err = dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+
+		stored, err := q.GetWebhookEventByWebhookEventID(ctx, event.WebhookEventID)
+		require.NoError(t, err)
+		assert.Equal(t, "failed", stored.Status)
+		assert.Equal(t, "No webhook configured", lo.FromPtr(stored.LastError))
+	})
+}
@@ -0,0 +1,98 @@
+package webhook
+
+// Payload is the request body sent to a bot's webhook URL.
+// See https://developers.line.biz/en/reference/messaging-api/#request-body
+type Payload struct {
+	Destination string  `json:"destination"`
+	Events      []Event `json:"events"`
+}
+
+// Event is a webhook event object.
+type Event struct {
+	Type            string          `json:"type"`
+	Message         *Message        `json:"message,omitempty"`
+	Follow          *Follow         `json:"follow,omitempty"`
+	Delivery        *Delivery       `json:"delivery,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId"`
+	DeliveryContext DeliveryContext `json:"deliveryContext"`
+	Timestamp       int64           `json:"timestamp"`
+	Source          *Source         `json:"source,omitempty"`
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Mode            string          `json:"mode"`
+}
+
+// Source describes where the event occurred.
+type Source struct {
+	Type    string `json:"type"`
+	UserID  string `json:"userId,omitempty"`
+	GroupID string `json:"groupId,omitempty"`
+	RoomID  string `json:"roomId,omitempty"`
+}
+
+// ChatID returns the ID of the user, group or room the event occurred in.
+func (s *Source) ChatID() string {
+	switch s.Type {
+	case SourceTypeGroup:
+		return s.GroupID
+	case SourceTypeRoom:
+		return s.RoomID
+	default:
+		return s.UserID
+	}
+}
+
+// DeliveryContext holds webhook delivery information.
+type DeliveryContext struct {
+	IsRedelivery bool `json:"isRedelivery"`
+}
+
+// Message is the message object of a message event.
+// Only the fields relevant to the message type are set.
+type Message struct {
+	ID                  string `json:"id"`
+	Type                string `json:"type"`
+	QuoteToken          string `json:"quoteToken,omitempty"`
+	Text                string `json:"text,omitempty"`
+	PackageID           string `json:"packageId,omitempty"`
+	StickerID           string `json:"stickerId,omitempty"`
+	StickerResourceType string `json:"stickerResourceType,omitempty"`
+	// ContentProvider is set for image, video and audio messages
+	ContentProvider *ContentProvider `json:"contentProvider,omitempty"`
+	// Duration is the length of videos and audio in milliseconds
+	Duration int64  `json:"duration,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+	FileSize int64  `json:"fileSize,omitempty"`
+}
+
+// ContentProvider tells where the content of a message is provided from.
+// Content sent by users is provided by LINE and can be downloaded with the get content endpoint.
+type ContentProvider struct {
+	Type string `json:"type"`
+}
+
+// Follow holds the details of a follow event.
+type Follow struct {
+	IsUnblocked bool `json:"isUnblocked"`
+}
+
+// Delivery holds the details of a delivery completion event, which is sent when a PNP message is delivered.
+type Delivery struct {
+	// Data is the X-Line-Delivery-Tag header of the request which sent the message
+	Data string `json:"data"`
+}
+
+const (
+	EventTypeMessage  = "message"
+	EventTypeFollow   = "follow"
+	EventTypeUnfollow = "unfollow"
+	EventTypeJoin     = "join"
+	EventTypeDelivery = "delivery"
+
+	SourceTypeUser  = "user"
+	SourceTypeGroup = "group"
+	SourceTypeRoom  = "room"
+
+	ModeActive = "active"
+
+	ContentProviderTypeLine = "line"
+)
@@ -0,0 +1,16 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Sign returns the X-Line-Signature value for the request body.
+// The signature is the Base64 encoded HMAC-SHA256 digest of the body using the channel secret as the key.
+// See https://developers.line.biz/en/reference/messaging-api/#signature-validation
+func Sign(channelSecret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(channelSecret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		channelSecret string
+		body          string
+		want          string
+	}{
+		{
+			name:          "empty events",
+			channelSecret: "testsecret",
+			body:          `{"destination":"U123","events":[]}`,
+			want:          "FbJ56+JptDzTg2B3aSe7YtHY31Mpm1JycvnMjDwJNkw=",
+		},
+		{
+			name:          "different secret produces different signature",
+			channelSecret: "othersecret",
+			body:          `{"destination":"U123","events":[]}`,
+			want:          "zqcBnifYu3eWq3jDMwbtn6MKsEUTok1mja8HUguuI04=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Sign(tt.channelSecret, []byte(tt.body)))
+		})
+	}
+}
@@ -1,7 +0,0 @@
-package xuuid
-
-import "github.com/google/uuid"
-
-var (
-	NameSpaceReplyToken = uuid.Must(uuid.Parse("6ba7b815-9dad-11d1-80b4-00c04fd430c8"))
-)
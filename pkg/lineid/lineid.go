@@ -0,0 +1,95 @@
+package lineid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+// epoch is the reference point of generated message IDs (LINE's launch date).
+// Using a recent epoch keeps the IDs within 19 digits for the foreseeable future.
+var epoch = time.Date(2011, time.June, 23, 0, 0, 0, 0, time.UTC)
+
+// crockfordAlphabet is the base32 alphabet used by ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// quoteTokenAlphabet is the alphabet used for quote tokens.
+const quoteTokenAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_"
+
+// NewMessageID returns a numeric message ID such as "468789577898262530".
+// IDs are time-ordered so that later messages have larger IDs.
+func NewMessageID() string {
+	millis := uint64(time.Since(epoch).Milliseconds())
+	id := millis<<22 | uint64(randomUint32()&0x3fffff)
+	return strconv.FormatUint(id, 10)
+}
+
+// NewWebhookEventID returns a ULID formatted webhook event ID such as "01FZ74A0TDDPYRVKNK77XKC3ZR".
+func NewWebhookEventID() string {
+	var b [16]byte
+	millis := uint64(time.Now().UnixMilli())
+	b[0] = byte(millis >> 40)
+	b[1] = byte(millis >> 32)
+	b[2] = byte(millis >> 24)
+	b[3] = byte(millis >> 16)
+	b[4] = byte(millis >> 8)
+	b[5] = byte(millis)
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(err)
+	}
+	return encodeCrockford(b)
+}
+
+// NewRequestID returns a request ID such as "123e4567-e89b-12d3-a456-426614174000", as returned in the X-Line-Request-Id header.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// NewReplyToken returns a reply token such as "757913772c4646b784d4b7ce46d12671".
+func NewReplyToken() string {
+	return randomHex(16)
+}
+
+// NewChannelSecret returns a channel secret such as "8c5ec2bd6f4a6e2dc5b3f4a9b7e8d1c0".
+func NewChannelSecret() string {
+	return randomHex(16)
+}
+
+// NewQuoteToken returns an opaque quote token.
+func NewQuoteToken() string {
+	return gonanoid.MustGenerate(quoteTokenAlphabet, 96)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func randomUint32() uint32 {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return binary.BigEndian.Uint32(b[:])
+}
+
+// encodeCrockford encodes 128 bits into 26 characters of Crockford's base32.
+func encodeCrockford(b [16]byte) string {
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
@@ -0,0 +1,52 @@
+package lineid
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewMessageID(t *testing.T) {
+	t.Parallel()
+
+	first := NewMessageID()
+	assert.Regexp(t, regexp.MustCompile(`^[1-9][0-9]{17,18}$`), first)
+	assert.NotEqual(t, first, NewMessageID())
+}
+
+func Test_NewWebhookEventID(t *testing.T) {
+	t.Parallel()
+
+	got := NewWebhookEventID()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`), got)
+}
+
+func Test_NewRequestID(t *testing.T) {
+	t.Parallel()
+
+	got := NewRequestID()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), got)
+	assert.NotEqual(t, got, NewRequestID())
+}
+
+func Test_NewReplyToken(t *testing.T) {
+	t.Parallel()
+
+	got := NewReplyToken()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), got)
+}
+
+func Test_NewChannelSecret(t *testing.T) {
+	t.Parallel()
+
+	got := NewChannelSecret()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), got)
+	assert.NotEqual(t, got, NewChannelSecret())
+}
+
+func Test_NewQuoteToken(t *testing.T) {
+	t.Parallel()
+
+	assert.Len(t, NewQuoteToken(), 96)
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	maxQuickReplyItems         = 13
+	maxActionDataLength        = 300
+	maxActionTextLength        = 300
+	maxActionURILength         = 1000
+	maxActionClipboardLength   = 1000
+	maxRichMenuAliasIDLength   = 32
+	maxQuickReplyImageURLength = 2000
+)
+
+var (
+	actionURISchemes = []string{"http", "https", "line", "tel"}
+
+	// datetimePickerLayouts are the formats of the initial, max and min values for each mode
+	datetimePickerLayouts = map[string]string{
+		"date":     "2006-01-02",
+		"time":     "15:04",
+		"datetime": "2006-01-02T15:04",
+	}
+	datetimePickerMinDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	datetimePickerMaxDate = time.Date(2100, 12, 31, 23, 59, 0, 0, time.UTC)
+)
+
+// actionRules are the rules of the actions which depend on where the actions are used
+type actionRules struct {
+	types          []string
+	labelRequired  bool
+	maxLabelLength int
+}
+
+var (
+	quickReplyActionRules = actionRules{
+		types:          []string{"postback", "message", "uri", "datetimepicker", "camera", "cameraRoll", "location", "clipboard"},
+		labelRequired:  true,
+		maxLabelLength: 20,
+	}
+	templateActionRules = actionRules{
+		types:          []string{"postback", "message", "uri", "datetimepicker", "clipboard"},
+		labelRequired:  true,
+		maxLabelLength: 20,
+	}
+	templateDefaultActionRules = actionRules{
+		types:          templateActionRules.types,
+		maxLabelLength: 20,
+	}
+	imageCarouselActionRules = actionRules{
+		types:          templateActionRules.types,
+		maxLabelLength: 12,
+	}
+	flexActionRules = actionRules{
+		types:          []string{"postback", "message", "uri", "datetimepicker", "clipboard"},
+		maxLabelLength: 40,
+	}
+	flexButtonActionRules = actionRules{
+		types:          flexActionRules.types,
+		labelRequired:  true,
+		maxLabelLength: 40,
+	}
+	richMenuActionRules = actionRules{
+		types:          []string{"postback", "message", "uri", "datetimepicker", "richmenuswitch", "clipboard"},
+		maxLabelLength: 20,
+	}
+)
+
+// validateActionProperty validates the action set to the property of the object
+func (v *objectValidator) validateActionProperty(obj map[string]any, path, key string, rules actionRules, required bool) {
+	var action map[string]any
+	var ok bool
+	if required {
+		action, ok = v.requireObject(obj, path, key)
+	} else {
+		action, ok = v.object(obj, path, key)
+	}
+	if ok {
+		v.validateAction(action, propertyPath(path, key), rules)
+	}
+}
+
+// validateAction validates an action object with the rules of its type
+func (v *objectValidator) validateAction(action map[string]any, path string, rules actionRules) {
+	actionType, ok := v.requireEnum(action, path, "type", rules.types...)
+	if !ok {
+		return
+	}
+	if rules.labelRequired {
+		v.requireString(action, path, "label", 1, rules.maxLabelLength)
+	} else {
+		v.optionalString(action, path, "label", 1, rules.maxLabelLength)
+	}
+
+	switch actionType {
+	case "postback":
+		v.requireString(action, path, "data", 1, maxActionDataLength)
+		_, hasDisplayText := v.optionalString(action, path, "displayText", 1, maxActionTextLength)
+		_, hasText := v.optionalString(action, path, "text", 1, maxActionTextLength)
+		if hasDisplayText && hasText {
+			v.fail(propertyPath(path, "text"), "displayText and text can't be specified at the same time")
+		}
+		v.optionalEnum(action, path, "inputOption", "closeRichMenu", "openRichMenu", "openKeyboard", "openVoice")
+		v.optionalString(action, path, "fillInText", 1, maxActionTextLength)
+	case "message":
+		v.requireString(action, path, "text", 1, maxActionTextLength)
+	case "uri":
+		v.requireURI(action, path, "uri", maxActionURILength)
+		if altURI, ok := v.object(action, path, "altUri"); ok {
+			v.requireURI(altURI, propertyPath(path, "altUri"), "desktop", maxActionURILength)
+		}
+	case "datetimepicker":
+		v.requireString(action, path, "data", 1, maxActionDataLength)
+		v.validateDatetimePicker(action, path)
+	case "richmenuswitch":
+		v.requireString(action, path, "richMenuAliasId", 1, maxRichMenuAliasIDLength)
+		v.requireString(action, path, "data", 1, maxActionDataLength)
+	case "clipboard":
+		v.requireString(action, path, "clipboardText", 1, maxActionClipboardLength)
+	}
+}
+
+// validateDatetimePicker checks that the initial, max and min values are in the format of the mode and are in order
+func (v *objectValidator) validateDatetimePicker(action map[string]any, path string) {
+	mode, ok := v.requireEnum(action, path, "mode", "date", "time", "datetime")
+	if !ok {
+		return
+	}
+
+	layout := datetimePickerLayouts[mode]
+	values := map[string]time.Time{}
+	for _, key := range []string{"initial", "max", "min"} {
+		s, ok := v.str(action, path, key)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			v.fail(propertyPath(path, key), fmt.Sprintf("must be in the %s format", layout))
+			continue
+		}
+		if mode != "time" && (t.Before(datetimePickerMinDate) || t.After(datetimePickerMaxDate)) {
+			v.fail(propertyPath(path, key), fmt.Sprintf("must be between %s and %s", datetimePickerMinDate.Format(layout), datetimePickerMaxDate.Format(layout)))
+			continue
+		}
+		values[key] = t
+	}
+
+	minValue, hasMin := values["min"]
+	maxValue, hasMax := values["max"]
+	if hasMin && hasMax && minValue.After(maxValue) {
+		v.fail(propertyPath(path, "min"), "must be before max")
+	}
+	if initial, ok := values["initial"]; ok {
+		if (hasMin && initial.Before(minValue)) || (hasMax && initial.After(maxValue)) {
+			v.fail(propertyPath(path, "initial"), "must be between min and max")
+		}
+	}
+}
+
+// requireURI checks that the property is set to a URI with one of the schemes actions can open
+func (v *objectValidator) requireURI(obj map[string]any, path, key string, maxLength int) (string, bool) {
+	s, ok := v.requireString(obj, path, key, 1, maxLength)
+	if !ok {
+		return s, false
+	}
+	if u, err := url.Parse(s); err != nil || !slices.Contains(actionURISchemes, strings.ToLower(u.Scheme)) {
+		v.fail(propertyPath(path, key), fmt.Sprintf("must be a URI with one of the schemes: %s", strings.Join(actionURISchemes, ", ")))
+		return s, false
+	}
+	return s, true
+}
+
+// validateQuickReply validates the quick reply buttons of a message
+func (v *objectValidator) validateQuickReply(message map[string]any, path string) {
+	quickReply, ok := v.object(message, path, "quickReply")
+	if !ok {
+		return
+	}
+
+	quickReplyPath := propertyPath(path, "quickReply")
+	items, ok := v.requireArray(quickReply, quickReplyPath, "items", 1, maxQuickReplyItems)
+	if !ok {
+		return
+	}
+	for i, element := range items {
+		itemPath := indexPath(propertyPath(quickReplyPath, "items"), i)
+		item, ok := v.elementObject(element, itemPath)
+		if !ok {
+			continue
+		}
+		v.requireEnum(item, itemPath, "type", "action")
+		v.optionalHTTPSURL(item, itemPath, "imageUrl", maxQuickReplyImageURLength)
+		v.validateActionProperty(item, itemPath, "action", quickReplyActionRules, true)
+	}
+}
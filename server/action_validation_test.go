@@ -0,0 +1,115 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAction(t *testing.T) {
+	// quickReply sets the actions as quick reply buttons of a text message
+	quickReply := func(actions ...string) string {
+		items := make([]string, 0, len(actions))
+		for _, action := range actions {
+			items = append(items, fmt.Sprintf(`{"type":"action","action":%s}`, action))
+		}
+		return fmt.Sprintf(`{"type":"text","text":"Select","quickReply":{"items":[%s]}}`, strings.Join(items, ","))
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		want    []validationDetail
+	}{
+		{
+			name: "quick reply with every action type",
+			message: quickReply(
+				`{"type":"postback","label":"Buy","data":"action=buy","displayText":"Buy","inputOption":"openKeyboard","fillInText":"---"}`,
+				`{"type":"message","label":"Yes","text":"Yes"}`,
+				`{"type":"uri","label":"Call","uri":"tel:09001234567","altUri":{"desktop":"https://example.com/desktop"}}`,
+				`{"type":"datetimepicker","label":"Date","data":"storeId=12345","mode":"datetime","initial":"2017-12-25T00:00","max":"2018-01-24T23:59","min":"2017-12-25T00:00"}`,
+				`{"type":"camera","label":"Camera"}`,
+				`{"type":"cameraRoll","label":"Camera roll"}`,
+				`{"type":"location","label":"Location"}`,
+				`{"type":"clipboard","label":"Copy","clipboardText":"3B48740B"}`,
+			),
+		},
+		{
+			name:    "too many quick reply items",
+			message: quickReply(strings.Split(strings.TrimSuffix(strings.Repeat(`{"type":"camera","label":"Camera"}|`, 14), "|"), "|")...),
+			want:    []validationDetail{{"messages[0].quickReply.items", "Size must be between 1 and 13"}},
+		},
+		{
+			name:    "quick reply item with an action type only available in rich menus",
+			message: quickReply(`{"type":"richmenuswitch","label":"Switch","richMenuAliasId":"richmenu-alias-b","data":"richmenu-changed-to-b"}`),
+			want:    []validationDetail{{"messages[0].quickReply.items[0].action.type", "must be one of: postback, message, uri, datetimepicker, camera, cameraRoll, location, clipboard"}},
+		},
+		{
+			name:    "quick reply item without label and type",
+			message: `{"type":"text","text":"Select","quickReply":{"items":[{"action":{"type":"location"}}]}}`,
+			want: []validationDetail{
+				{"messages[0].quickReply.items[0].type", "must be specified"},
+				{"messages[0].quickReply.items[0].action.label", "must be specified"},
+			},
+		},
+		{
+			name:    "label too long",
+			message: quickReply(`{"type":"message","label":"` + strings.Repeat("a", 21) + `","text":"Yes"}`),
+			want:    []validationDetail{{"messages[0].quickReply.items[0].action.label", "Length must be between 1 and 20"}},
+		},
+		{
+			name:    "postback with too long data and both displayText and text",
+			message: quickReply(`{"type":"postback","label":"Buy","data":"` + strings.Repeat("a", 301) + `","displayText":"Buy","text":"Buy"}`),
+			want: []validationDetail{
+				{"messages[0].quickReply.items[0].action.data", "Length must be between 1 and 300"},
+				{"messages[0].quickReply.items[0].action.text", "displayText and text can't be specified at the same time"},
+			},
+		},
+		{
+			name:    "uri with unsupported scheme",
+			message: quickReply(`{"type":"uri","label":"Open","uri":"ftp://example.com/file"}`),
+			want:    []validationDetail{{"messages[0].quickReply.items[0].action.uri", "must be a URI with one of the schemes: http, https, line, tel"}},
+		},
+		{
+			name:    "datetimepicker with invalid mode",
+			message: quickReply(`{"type":"datetimepicker","label":"Date","data":"a","mode":"month"}`),
+			want:    []validationDetail{{"messages[0].quickReply.items[0].action.mode", "must be one of: date, time, datetime"}},
+		},
+		{
+			name:    "datetimepicker with values in the wrong format and order",
+			message: quickReply(`{"type":"datetimepicker","label":"Date","data":"a","mode":"date","initial":"2017-12-25T00:00","max":"2017-01-01","min":"2017-12-31"}`),
+			want: []validationDetail{
+				{"messages[0].quickReply.items[0].action.initial", "must be in the 2006-01-02 format"},
+				{"messages[0].quickReply.items[0].action.min", "must be before max"},
+			},
+		},
+		{
+			name:    "datetimepicker with a date out of range",
+			message: quickReply(`{"type":"datetimepicker","label":"Date","data":"a","mode":"date","initial":"1899-12-31"}`),
+			want:    []validationDetail{{"messages[0].quickReply.items[0].action.initial", "must be between 1900-01-01 and 2100-12-31"}},
+		},
+		{
+			name:    "template action without label",
+			message: `{"type":"template","altText":"Template","template":{"type":"buttons","text":"Please select","actions":[{"type":"message","text":"Yes"}]}}`,
+			want:    []validationDetail{{"messages[0].template.actions[0].label", "must be specified"}},
+		},
+		{
+			name:    "flex button with invalid action",
+			message: `{"type":"flex","altText":"Flex","contents":{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"button","action":{"type":"camera","label":"Camera"}}]}}}`,
+			want:    []validationDetail{{"messages[0].contents.body.contents[0].action.type", "must be one of: postback, message, uri, datetimepicker, clipboard"}},
+		},
+		{
+			name:    "imagemap action with unsupported scheme",
+			message: `{"type":"imagemap","baseUrl":"https://example.com/bot/images/rm001","altText":"Imagemap","baseSize":{"width":1040,"height":1040},"actions":[{"type":"uri","linkUri":"javascript:alert(1)","area":{"x":0,"y":0,"width":1040,"height":1040}}]}`,
+			want:    []validationDetail{{"messages[0].actions[0].linkUri", "must be a URI with one of the schemes: http, https, line, tel"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validationDetails(t, tt.message))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
 	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
 	"github.com/zero-color/line-messaging-api-emulator/pkg/pgutil"
 	"github.com/zero-color/line-messaging-api-emulator/pkg/shortid"
 )
@@ -52,14 +53,23 @@ func (s *server) CreateBot(ctx context.Context, request adminapi.CreateBotReques
 		premiumID = request.Body.PremiumId
 	}
 
+	var channelSecret string
+	if request.Body.ChannelSecret != nil && *request.Body.ChannelSecret != "" {
+		channelSecret = *request.Body.ChannelSecret
+	} else {
+		channelSecret = lineid.NewChannelSecret()
+	}
+
 	bot, err := s.db.CreateBot(ctx, db.CreateBotParams{
-		UserID:         userID,
-		BasicID:        basicID,
-		ChatMode:       chatMode,
-		DisplayName:    request.Body.DisplayName,
-		MarkAsReadMode: markAsReadMode,
-		PictureUrl:     pictureURL,
-		PremiumID:      premiumID,
+		UserID:            userID,
+		BasicID:           basicID,
+		ChatMode:          chatMode,
+		DisplayName:       request.Body.DisplayName,
+		MarkAsReadMode:    markAsReadMode,
+		PictureUrl:        pictureURL,
+		PremiumID:         premiumID,
+		ChannelSecret:     channelSecret,
+		WebhookRedelivery: lo.FromPtr(request.Body.WebhookRedelivery),
 	})
 
 	if err != nil {
@@ -85,17 +95,66 @@ func (s *server) CreateBot(ctx context.Context, request adminapi.CreateBotReques
 		}, nil
 	}
 
-	response := adminapi.BotInfoResponse{
-		BasicId:        bot.BasicID,
-		ChatMode:       adminapi.BotInfoResponseChatMode(bot.ChatMode),
-		DisplayName:    bot.DisplayName,
-		MarkAsReadMode: adminapi.BotInfoResponseMarkAsReadMode(bot.MarkAsReadMode),
-		PictureUrl:     bot.PictureUrl,
-		PremiumId:      bot.PremiumID,
-		UserId:         bot.UserID,
+	return adminapi.CreateBot201JSONResponse(buildBotInfoResponse(bot)), nil
+}
+
+// SetWebhookRedelivery enables or disables webhook redelivery for a bot
+func (s *server) SetWebhookRedelivery(ctx context.Context, request adminapi.SetWebhookRedeliveryRequestObject) (adminapi.SetWebhookRedeliveryResponseObject, error) {
+	if request.Body == nil {
+		return adminapi.SetWebhookRedelivery400JSONResponse(newAdminError("INVALID_REQUEST", "Request body is required")), nil
+	}
+
+	bot, err := s.db.UpdateBotWebhookRedelivery(ctx, db.UpdateBotWebhookRedeliveryParams{
+		WebhookRedelivery: request.Body.Enabled,
+		UserID:            request.BotId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.SetWebhookRedelivery404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.SetWebhookRedelivery500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to update bot: %v", err))), nil
 	}
 
-	return adminapi.CreateBot201JSONResponse(response), nil
+	return adminapi.SetWebhookRedelivery200JSONResponse(buildBotInfoResponse(bot)), nil
+}
+
+// SetMessageQuotaPlan sets the plan the monthly limit of messages of a bot is determined by
+func (s *server) SetMessageQuotaPlan(ctx context.Context, request adminapi.SetMessageQuotaPlanRequestObject) (adminapi.SetMessageQuotaPlanResponseObject, error) {
+	if request.Body == nil {
+		return adminapi.SetMessageQuotaPlan400JSONResponse(newAdminError("INVALID_REQUEST", "Request body is required")), nil
+	}
+	if _, limited := messageQuotaLimits[string(request.Body.Plan)]; !limited && request.Body.Plan != adminapi.PlanNone {
+		return adminapi.SetMessageQuotaPlan400JSONResponse(newAdminError("INVALID_REQUEST", fmt.Sprintf("Unknown plan: %s", request.Body.Plan))), nil
+	}
+
+	bot, err := s.db.UpdateBotMessageQuotaPlan(ctx, db.UpdateBotMessageQuotaPlanParams{
+		MessageQuotaPlan: string(request.Body.Plan),
+		UserID:           request.BotId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.SetMessageQuotaPlan404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.SetMessageQuotaPlan500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to update bot: %v", err))), nil
+	}
+
+	return adminapi.SetMessageQuotaPlan200JSONResponse(buildBotInfoResponse(bot)), nil
+}
+
+// buildBotInfoResponse converts a database bot to an API response
+func buildBotInfoResponse(bot db.Bot) adminapi.BotInfoResponse {
+	return adminapi.BotInfoResponse{
+		BasicId:           bot.BasicID,
+		ChannelSecret:     bot.ChannelSecret,
+		ChatMode:          adminapi.BotInfoResponseChatMode(bot.ChatMode),
+		DisplayName:       bot.DisplayName,
+		MarkAsReadMode:    adminapi.BotInfoResponseMarkAsReadMode(bot.MarkAsReadMode),
+		PictureUrl:        bot.PictureUrl,
+		PremiumId:         bot.PremiumID,
+		UserId:            bot.UserID,
+		WebhookRedelivery: bot.WebhookRedelivery,
+		MessageQuotaPlan:  adminapi.BotInfoResponseMessageQuotaPlan(bot.MessageQuotaPlan),
+	}
 }
 
 // CreateFollowers creates dummy followers for a bot using bulk insert
@@ -341,3 +400,11 @@ func (s *server) buildFollowerProfiles(users []db.User) []adminapi.FollowerProfi
 
 	return followers
 }
+
+// newAdminError builds an admin API error response
+func newAdminError(code, message string) adminapi.ErrorResponse {
+	var response adminapi.ErrorResponse
+	response.Error.Code = &code
+	response.Error.Message = message
+	return response
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+// JoinChat makes a bot join a new group chat or multi-person chat and queues the join event
+func (s *server) JoinChat(ctx context.Context, request adminapi.JoinChatRequestObject) (adminapi.JoinChatResponseObject, error) {
+	bot, err := s.db.GetBotByUserID(ctx, request.BotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.JoinChat404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.JoinChat500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))), nil
+	}
+
+	if request.Body == nil {
+		return adminapi.JoinChat400JSONResponse(newAdminError("INVALID_REQUEST", "Request body is required")), nil
+	}
+
+	// Group IDs start with C and room IDs start with R, as on LINE
+	source := &webhook.Source{}
+	switch request.Body.Type {
+	case adminapi.ChatTypeGroup:
+		source.Type = webhook.SourceTypeGroup
+		source.GroupID = "C" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	case adminapi.ChatTypeRoom:
+		source.Type = webhook.SourceTypeRoom
+		source.RoomID = "R" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	default:
+		return adminapi.JoinChat400JSONResponse(newAdminError("INVALID_REQUEST", fmt.Sprintf("Unsupported chat type: %s", request.Body.Type))), nil
+	}
+
+	if _, err := s.db.CreateBotChat(ctx, db.CreateBotChatParams{
+		BotID:    bot.ID,
+		ChatType: source.Type,
+		ChatID:   source.ChatID(),
+	}); err != nil {
+		return adminapi.JoinChat500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to join chat: %v", err))), nil
+	}
+
+	event := webhook.Event{
+		Type:           webhook.EventTypeJoin,
+		WebhookEventID: lineid.NewWebhookEventID(),
+		Timestamp:      time.Now().UnixMilli(),
+		Source:         source,
+		ReplyToken:     lineid.NewReplyToken(),
+		Mode:           webhook.ModeActive,
+	}
+	if err := webhook.Enqueue(ctx, s.db, bot.ID, s.now(), event); err != nil {
+		return adminapi.JoinChat500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to queue webhook event: %v", err))), nil
+	}
+
+	return adminapi.JoinChat202JSONResponse{
+		ChatId:         source.ChatID(),
+		WebhookEventId: event.WebhookEventID,
+		ReplyToken:     event.ReplyToken,
+	}, nil
+}
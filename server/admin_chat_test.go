@@ -0,0 +1,69 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestJoinChat(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	tests := []struct {
+		chatType   adminapi.JoinChatRequestType
+		prefix     string
+		sourceType string
+	}{
+		{adminapi.ChatTypeGroup, "C", webhook.SourceTypeGroup},
+		{adminapi.ChatTypeRoom, "R", webhook.SourceTypeRoom},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.chatType), func(t *testing.T) {
+			resp, err := srv.JoinChat(ctx, adminapi.JoinChatRequestObject{
+				BotId: createdBot.UserId,
+				Body:  &adminapi.JoinChatRequest{Type: tt.chatType},
+			})
+			require.NoError(t, err)
+			joinResp, ok := resp.(adminapi.JoinChat202JSONResponse)
+			require.True(t, ok, "Expected JoinChat202JSONResponse, got %T", resp)
+			assert.Regexp(t, "^"+tt.prefix+"[0-9a-f]{32}$", joinResp.ChatId)
+
+			stored, err := dbClient.GetWebhookEventByWebhookEventID(ctx, joinResp.WebhookEventId)
+			require.NoError(t, err)
+			var event webhook.Event
+			require.NoError(t, json.Unmarshal(stored.Payload, &event))
+			assert.Equal(t, webhook.EventTypeJoin, event.Type)
+			assert.Equal(t, joinResp.ReplyToken, event.ReplyToken)
+			require.NotNil(t, event.Source)
+			assert.Equal(t, tt.sourceType, event.Source.Type)
+			assert.Equal(t, joinResp.ChatId, event.Source.ChatID())
+		})
+	}
+
+	t.Run("unknown bot", func(t *testing.T) {
+		resp, err := srv.JoinChat(ctx, adminapi.JoinChatRequestObject{
+			BotId: "U_unknown",
+			Body:  &adminapi.JoinChatRequest{Type: adminapi.ChatTypeGroup},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.JoinChat404JSONResponse{}, resp)
+	})
+}
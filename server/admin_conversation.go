@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+// GetConversation lists the messages in the chat between a bot and a user, group or room, oldest first
+func (s *server) GetConversation(ctx context.Context, request adminapi.GetConversationRequestObject) (adminapi.GetConversationResponseObject, error) {
+	bot, err := s.db.GetBotByUserID(ctx, request.BotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.GetConversation404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.GetConversation500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))), nil
+	}
+
+	messages, err := s.db.ListConversationMessages(ctx, db.ListConversationMessagesParams{
+		BotID:  bot.ID,
+		ChatID: request.ChatId,
+	})
+	if err != nil {
+		return adminapi.GetConversation500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to list conversation messages: %v", err))), nil
+	}
+
+	response := adminapi.GetConversation200JSONResponse{
+		Messages: make([]adminapi.ConversationMessage, 0, len(messages)),
+	}
+	for _, message := range messages {
+		var content map[string]interface{}
+		if err := json.Unmarshal(message.Content, &content); err != nil {
+			return adminapi.GetConversation500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to parse conversation message: %v", err))), nil
+		}
+		conversationMessage := adminapi.ConversationMessage{
+			Id:          message.ID,
+			Sender:      adminapi.ConversationMessageSender(message.Sender),
+			MessageType: message.MessageType,
+			Message:     content,
+			CreatedAt:   message.CreatedAt.Time,
+		}
+		if message.ReadAt.Valid {
+			conversationMessage.ReadAt = &message.ReadAt.Time
+		}
+		response.Messages = append(response.Messages, conversationMessage)
+	}
+	return response, nil
+}
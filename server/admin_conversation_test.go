@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestGetConversation(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	for _, userID := range []string{"U_alice", "U_bob"} {
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+		_, err = srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: userID,
+		})
+		require.NoError(t, err)
+	}
+
+	getConversation := func(t *testing.T, chatID string) []adminapi.ConversationMessage {
+		t.Helper()
+		resp, err := srv.GetConversation(ctx, adminapi.GetConversationRequestObject{
+			BotId:  createdBot.UserId,
+			ChatId: chatID,
+		})
+		require.NoError(t, err)
+		conversation, ok := resp.(adminapi.GetConversation200JSONResponse)
+		require.True(t, ok, "Expected GetConversation200JSONResponse, got %T", resp)
+		return conversation.Messages
+	}
+
+	pushBody := map[string]any{
+		"to": "U_alice",
+		"messages": []map[string]any{
+			{"type": "text", "text": "Hello, Alice"},
+			{"type": "sticker", "packageId": "446", "stickerId": "1988"},
+		},
+	}
+	_, err = srv.PushMessage(withRequestBody(t, botCtx, pushBody), messagingapi.PushMessageRequestObject{
+		Body: &messagingapi.PushMessageRequest{
+			To:       "U_alice",
+			Messages: []messagingapi.Message{{Type: "text"}, {Type: "sticker"}},
+		},
+	})
+	require.NoError(t, err)
+
+	multicastBody := map[string]any{
+		"to":       []string{"U_alice", "U_bob"},
+		"messages": []map[string]any{{"type": "text", "text": "Hello, everyone"}},
+	}
+	_, err = srv.Multicast(withRequestBody(t, botCtx, multicastBody), messagingapi.MulticastRequestObject{
+		Body: &messagingapi.MulticastRequest{
+			To:       []string{"U_alice", "U_bob"},
+			Messages: []messagingapi.Message{{Type: "text"}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+		BotId:  createdBot.UserId,
+		UserId: "U_bob",
+		Body: &adminapi.SendUserMessageRequest{
+			Type: adminapi.Text,
+			Text: lo.ToPtr("Hi, bot"),
+		},
+	})
+	require.NoError(t, err)
+
+	broadcastBody := map[string]any{
+		"messages": []map[string]any{{"type": "text", "text": "News"}},
+	}
+	_, err = srv.Broadcast(withRequestBody(t, botCtx, broadcastBody), messagingapi.BroadcastRequestObject{
+		Body: &messagingapi.BroadcastRequest{
+			Messages: []messagingapi.Message{{Type: "text"}},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("lists every message a user received in order", func(t *testing.T) {
+		messages := getConversation(t, "U_alice")
+		require.Len(t, messages, 4)
+
+		assert.Equal(t, adminapi.SenderBot, messages[0].Sender)
+		assert.Equal(t, "push", lo.FromPtr(messages[0].MessageType))
+		assert.Equal(t, "Hello, Alice", messages[0].Message["text"])
+
+		assert.Equal(t, "push", lo.FromPtr(messages[1].MessageType))
+		assert.Equal(t, "sticker", messages[1].Message["type"])
+		assert.Equal(t, "1988", messages[1].Message["stickerId"])
+
+		assert.Equal(t, "multicast", lo.FromPtr(messages[2].MessageType))
+		assert.Equal(t, "Hello, everyone", messages[2].Message["text"])
+
+		assert.Equal(t, "broadcast", lo.FromPtr(messages[3].MessageType))
+		assert.Equal(t, "News", messages[3].Message["text"])
+	})
+
+	t.Run("includes messages sent by the user", func(t *testing.T) {
+		messages := getConversation(t, "U_bob")
+		require.Len(t, messages, 3)
+
+		assert.Equal(t, "multicast", lo.FromPtr(messages[0].MessageType))
+
+		assert.Equal(t, adminapi.SenderUser, messages[1].Sender)
+		assert.Nil(t, messages[1].MessageType)
+		assert.Equal(t, "Hi, bot", messages[1].Message["text"])
+
+		assert.Equal(t, "broadcast", lo.FromPtr(messages[2].MessageType))
+	})
+
+	t.Run("returns empty conversation for unknown chat", func(t *testing.T) {
+		assert.Empty(t, getConversation(t, "U_unknown"))
+	})
+
+	t.Run("returns 404 for non-existent bot", func(t *testing.T) {
+		resp, err := srv.GetConversation(ctx, adminapi.GetConversationRequestObject{
+			BotId:  "U_nonexistent_bot",
+			ChatId: "U_alice",
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.GetConversation404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+}
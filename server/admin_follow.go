@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+type followAction int
+
+const (
+	followActionFollow followAction = iota
+	followActionBlock
+	followActionUnblock
+)
+
+// followStateError is returned when the follow state of a user can't be changed
+type followStateError struct {
+	status int
+	body   adminapi.ErrorResponse
+}
+
+// FollowBot makes an emulated user follow a bot, unblocking it if the user has blocked it
+func (s *server) FollowBot(ctx context.Context, request adminapi.FollowBotRequestObject) (adminapi.FollowBotResponseObject, error) {
+	resp, stateErr := s.changeFollowState(ctx, request.BotId, request.UserId, followActionFollow)
+	if stateErr != nil {
+		switch stateErr.status {
+		case http.StatusNotFound:
+			return adminapi.FollowBot404JSONResponse(stateErr.body), nil
+		case http.StatusConflict:
+			return adminapi.FollowBot409JSONResponse(stateErr.body), nil
+		default:
+			return adminapi.FollowBot500JSONResponse(stateErr.body), nil
+		}
+	}
+	return adminapi.FollowBot202JSONResponse(resp), nil
+}
+
+// BlockBot makes an emulated user block a bot
+func (s *server) BlockBot(ctx context.Context, request adminapi.BlockBotRequestObject) (adminapi.BlockBotResponseObject, error) {
+	resp, stateErr := s.changeFollowState(ctx, request.BotId, request.UserId, followActionBlock)
+	if stateErr != nil {
+		switch stateErr.status {
+		case http.StatusNotFound:
+			return adminapi.BlockBot404JSONResponse(stateErr.body), nil
+		case http.StatusConflict:
+			return adminapi.BlockBot409JSONResponse(stateErr.body), nil
+		default:
+			return adminapi.BlockBot500JSONResponse(stateErr.body), nil
+		}
+	}
+	return adminapi.BlockBot202JSONResponse(resp), nil
+}
+
+// UnblockBot makes an emulated user unblock a bot
+func (s *server) UnblockBot(ctx context.Context, request adminapi.UnblockBotRequestObject) (adminapi.UnblockBotResponseObject, error) {
+	resp, stateErr := s.changeFollowState(ctx, request.BotId, request.UserId, followActionUnblock)
+	if stateErr != nil {
+		switch stateErr.status {
+		case http.StatusNotFound:
+			return adminapi.UnblockBot404JSONResponse(stateErr.body), nil
+		case http.StatusConflict:
+			return adminapi.UnblockBot409JSONResponse(stateErr.body), nil
+		default:
+			return adminapi.UnblockBot500JSONResponse(stateErr.body), nil
+		}
+	}
+	return adminapi.UnblockBot202JSONResponse(resp), nil
+}
+
+// changeFollowState updates the relationship between a user and a bot and queues the follow or unfollow event
+func (s *server) changeFollowState(ctx context.Context, botUserID, userID string, action followAction) (adminapi.FollowStateResponse, *followStateError) {
+	newError := func(status int, code, message string) *followStateError {
+		return &followStateError{status: status, body: newAdminError(code, message)}
+	}
+
+	bot, err := s.db.GetBotByUserID(ctx, botUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.FollowStateResponse{}, newError(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", botUserID))
+		}
+		return adminapi.FollowStateResponse{}, newError(http.StatusInternalServerError, "INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))
+	}
+
+	user, err := s.db.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.FollowStateResponse{}, newError(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("User with user ID %s not found", userID))
+		}
+		return adminapi.FollowStateResponse{}, newError(http.StatusInternalServerError, "INTERNAL_ERROR", fmt.Sprintf("Failed to get user: %v", err))
+	}
+
+	var following, blocked bool
+	follower, err := s.db.GetBotFollower(ctx, db.GetBotFollowerParams{BotID: bot.ID, UserID: user.ID})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.FollowStateResponse{}, newError(http.StatusInternalServerError, "INTERNAL_ERROR", fmt.Sprintf("Failed to get follower: %v", err))
+		}
+	} else {
+		blocked = follower.BlockedAt.Valid
+		following = !blocked
+	}
+
+	event := webhook.Event{
+		WebhookEventID: lineid.NewWebhookEventID(),
+		Timestamp:      time.Now().UnixMilli(),
+		Source: &webhook.Source{
+			Type:   webhook.SourceTypeUser,
+			UserID: user.UserID,
+		},
+		Mode: webhook.ModeActive,
+	}
+	params := db.GetBotFollowerParams{BotID: bot.ID, UserID: user.ID}
+
+	switch action {
+	case followActionFollow, followActionUnblock:
+		if action == followActionFollow && following {
+			return adminapi.FollowStateResponse{}, newError(http.StatusConflict, "CONFLICT", fmt.Sprintf("User %s already follows the bot", userID))
+		}
+		if action == followActionUnblock && !blocked {
+			return adminapi.FollowStateResponse{}, newError(http.StatusConflict, "CONFLICT", fmt.Sprintf("User %s hasn't blocked the bot", userID))
+		}
+		if _, err := s.db.UpsertBotFollower(ctx, db.UpsertBotFollowerParams(params)); err != nil {
+			return adminapi.FollowStateResponse{}, newError(http.StatusInternalServerError, "INTERNAL_ERROR", fmt.Sprintf("Failed to follow bot: %v", err))
+		}
+		event.Type = webhook.EventTypeFollow
+		event.Follow = &webhook.Follow{IsUnblocked: blocked}
+		event.ReplyToken = lineid.NewReplyToken()
+	case followActionBlock:
+		if !following {
+			return adminapi.FollowStateResponse{}, newError(http.StatusConflict, "CONFLICT", fmt.Sprintf("User %s doesn't follow the bot", userID))
+		}
+		if _, err := s.db.BlockBotFollower(ctx, db.BlockBotFollowerParams(params)); err != nil {
+			return adminapi.FollowStateResponse{}, newError(http.StatusInternalServerError, "INTERNAL_ERROR", fmt.Sprintf("Failed to block bot: %v", err))
+		}
+		event.Type = webhook.EventTypeUnfollow
+	}
+
+	if err := webhook.Enqueue(ctx, s.db, bot.ID, s.now(), event); err != nil {
+		return adminapi.FollowStateResponse{}, newError(http.StatusInternalServerError, "INTERNAL_ERROR", fmt.Sprintf("Failed to queue webhook event: %v", err))
+	}
+
+	resp := adminapi.FollowStateResponse{
+		Status:         adminapi.Following,
+		WebhookEventId: event.WebhookEventID,
+	}
+	if event.Type == webhook.EventTypeUnfollow {
+		resp.Status = adminapi.Blocked
+	} else {
+		resp.ReplyToken = &event.ReplyToken
+	}
+	return resp, nil
+}
@@ -0,0 +1,262 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestFollowLifecycle(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	user, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_follower",
+		DisplayName: "Follower",
+	})
+	require.NoError(t, err)
+
+	queuedEvent := func(t *testing.T, webhookEventID string) webhook.Event {
+		t.Helper()
+		stored, err := dbClient.GetWebhookEventByWebhookEventID(ctx, webhookEventID)
+		require.NoError(t, err)
+		var event webhook.Event
+		require.NoError(t, json.Unmarshal(stored.Payload, &event))
+		return event
+	}
+
+	isFollower := func(t *testing.T) bool {
+		t.Helper()
+		following, err := dbClient.IsBotFollower(ctx, db.IsBotFollowerParams{BotID: bot.ID, UserID: user.UserID})
+		require.NoError(t, err)
+		return following
+	}
+
+	t.Run("follow queues follow event", func(t *testing.T) {
+		resp, err := srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		followResp, ok := resp.(adminapi.FollowBot202JSONResponse)
+		require.True(t, ok, "Expected FollowBot202JSONResponse, got %T", resp)
+		assert.Equal(t, adminapi.Following, followResp.Status)
+		require.NotNil(t, followResp.ReplyToken)
+		assert.True(t, isFollower(t))
+
+		event := queuedEvent(t, followResp.WebhookEventId)
+		assert.Equal(t, "follow", event.Type)
+		require.NotNil(t, event.Follow)
+		assert.False(t, event.Follow.IsUnblocked)
+		assert.Equal(t, *followResp.ReplyToken, event.ReplyToken)
+		assert.Equal(t, user.UserID, event.Source.UserID)
+	})
+
+	t.Run("follow twice returns conflict", func(t *testing.T) {
+		resp, err := srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.FollowBot409JSONResponse)
+		assert.True(t, ok, "Expected 409 response, got %T", resp)
+	})
+
+	t.Run("unblock without blocking returns conflict", func(t *testing.T) {
+		resp, err := srv.UnblockBot(ctx, adminapi.UnblockBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.UnblockBot409JSONResponse)
+		assert.True(t, ok, "Expected 409 response, got %T", resp)
+	})
+
+	t.Run("block queues unfollow event", func(t *testing.T) {
+		resp, err := srv.BlockBot(ctx, adminapi.BlockBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		blockResp, ok := resp.(adminapi.BlockBot202JSONResponse)
+		require.True(t, ok, "Expected BlockBot202JSONResponse, got %T", resp)
+		assert.Equal(t, adminapi.Blocked, blockResp.Status)
+		assert.Nil(t, blockResp.ReplyToken)
+		assert.False(t, isFollower(t))
+
+		event := queuedEvent(t, blockResp.WebhookEventId)
+		assert.Equal(t, "unfollow", event.Type)
+		assert.Nil(t, event.Follow)
+		assert.Empty(t, event.ReplyToken)
+	})
+
+	t.Run("blocked user is excluded from followers", func(t *testing.T) {
+		count, err := dbClient.GetBotFollowerCount(ctx, bot.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		resp, err := srv.GetProfile(botCtx, messagingapi.GetProfileRequestObject{UserId: user.UserID})
+		assert.Error(t, err, "Expected error for blocked user, got %T", resp)
+	})
+
+	t.Run("push to blocked user is dropped", func(t *testing.T) {
+		pushCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       user.UserID,
+			"messages": []map[string]any{{"type": "text", "text": "Hello"}},
+		})
+		resp, err := srv.PushMessage(pushCtx, messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To:       user.UserID,
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(messagingapi.PushMessage200JSONResponse)
+		require.True(t, ok, "Expected PushMessage200JSONResponse, got %T", resp)
+
+		// The message is accepted but delivered to no one
+		messages, err := dbClient.GetBotMessages(ctx, db.GetBotMessagesParams{BotID: bot.ID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		deliveries, err := dbClient.ListMessageDeliveries(ctx, messages[0].ID)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("multicast drops blocked users", func(t *testing.T) {
+		other, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      "U_other",
+			DisplayName: "Other",
+		})
+		require.NoError(t, err)
+
+		multicastCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       []string{user.UserID, other.UserID},
+			"messages": []map[string]any{{"type": "text", "text": "Hello"}},
+		})
+		_, err = srv.Multicast(multicastCtx, messagingapi.MulticastRequestObject{
+			Body: &messagingapi.MulticastRequest{
+				To:       []string{user.UserID, other.UserID},
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+
+		messages, err := dbClient.GetBotMessages(ctx, db.GetBotMessagesParams{BotID: bot.ID, Limit: 10})
+		require.NoError(t, err)
+		multicast, ok := lo.Find(messages, func(m db.Message) bool { return m.MessageType == "multicast" })
+		require.True(t, ok)
+		deliveries, err := dbClient.ListMessageDeliveries(ctx, multicast.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, other.UserID, deliveries[0].RecipientID)
+	})
+
+	t.Run("unblock queues follow event with isUnblocked", func(t *testing.T) {
+		resp, err := srv.UnblockBot(ctx, adminapi.UnblockBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		unblockResp, ok := resp.(adminapi.UnblockBot202JSONResponse)
+		require.True(t, ok, "Expected UnblockBot202JSONResponse, got %T", resp)
+		assert.Equal(t, adminapi.Following, unblockResp.Status)
+		assert.True(t, isFollower(t))
+
+		event := queuedEvent(t, unblockResp.WebhookEventId)
+		assert.Equal(t, "follow", event.Type)
+		require.NotNil(t, event.Follow)
+		assert.True(t, event.Follow.IsUnblocked)
+	})
+
+	t.Run("follow after block re-follows with isUnblocked", func(t *testing.T) {
+		_, err := srv.BlockBot(ctx, adminapi.BlockBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		resp, err := srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		followResp, ok := resp.(adminapi.FollowBot202JSONResponse)
+		require.True(t, ok, "Expected FollowBot202JSONResponse, got %T", resp)
+		assert.True(t, isFollower(t))
+
+		event := queuedEvent(t, followResp.WebhookEventId)
+		require.NotNil(t, event.Follow)
+		assert.True(t, event.Follow.IsUnblocked)
+	})
+
+	t.Run("block by non-follower returns conflict", func(t *testing.T) {
+		stranger, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      "U_stranger",
+			DisplayName: "Stranger",
+		})
+		require.NoError(t, err)
+
+		resp, err := srv.BlockBot(ctx, adminapi.BlockBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: stranger.UserID,
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.BlockBot409JSONResponse)
+		assert.True(t, ok, "Expected 409 response, got %T", resp)
+	})
+
+	t.Run("returns 404 for non-existent user", func(t *testing.T) {
+		resp, err := srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: "U_nonexistent",
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.FollowBot404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+
+	t.Run("returns 404 for non-existent bot", func(t *testing.T) {
+		resp, err := srv.BlockBot(ctx, adminapi.BlockBotRequestObject{
+			BotId:  "U_nonexistent_bot",
+			UserId: user.UserID,
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.BlockBot404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+}
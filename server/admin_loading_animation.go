@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+// GetLoadingAnimation returns whether the loading animation the bot started is shown in the chat with a user
+func (s *server) GetLoadingAnimation(ctx context.Context, request adminapi.GetLoadingAnimationRequestObject) (adminapi.GetLoadingAnimationResponseObject, error) {
+	bot, err := s.db.GetBotByUserID(ctx, request.BotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.GetLoadingAnimation404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.GetLoadingAnimation500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))), nil
+	}
+
+	animation, err := s.db.GetActiveLoadingAnimation(ctx, db.GetActiveLoadingAnimationParams{
+		BotID:  bot.ID,
+		ChatID: request.ChatId,
+		Now:    pgtype.Timestamptz{Time: s.now(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.GetLoadingAnimation200JSONResponse{Active: false}, nil
+		}
+		return adminapi.GetLoadingAnimation500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get loading animation: %v", err))), nil
+	}
+
+	return adminapi.GetLoadingAnimation200JSONResponse{
+		Active:         true,
+		LoadingSeconds: &animation.LoadingSeconds,
+		StartedAt:      &animation.StartedAt.Time,
+		ExpiresAt:      &animation.ExpiresAt.Time,
+	}, nil
+}
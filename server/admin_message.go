@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+// SendUserMessage simulates a user sending a message to a bot and queues the message event for delivery to the bot's webhook
+func (s *server) SendUserMessage(ctx context.Context, request adminapi.SendUserMessageRequestObject) (adminapi.SendUserMessageResponseObject, error) {
+	bot, err := s.db.GetBotByUserID(ctx, request.BotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.SendUserMessage404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.SendUserMessage500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))), nil
+	}
+
+	user, err := s.db.GetUser(ctx, request.UserId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.SendUserMessage404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("User with user ID %s not found", request.UserId))), nil
+		}
+		return adminapi.SendUserMessage500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get user: %v", err))), nil
+	}
+
+	if request.Body == nil {
+		return adminapi.SendUserMessage400JSONResponse(newAdminError("INVALID_REQUEST", "Request body is required")), nil
+	}
+
+	message, media, err := buildUserMessage(*request.Body)
+	if err != nil {
+		return adminapi.SendUserMessage400JSONResponse(newAdminError("INVALID_REQUEST", err.Error())), nil
+	}
+	// The content is stored before the event is queued so that the bot can download it as soon as it receives the event
+	if media != nil {
+		if err := s.storeMessageContent(ctx, bot.ID, message, media); err != nil {
+			return adminapi.SendUserMessage500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to store message content: %v", err))), nil
+		}
+	}
+
+	event := webhook.Event{
+		Type:           webhook.EventTypeMessage,
+		Message:        message,
+		WebhookEventID: lineid.NewWebhookEventID(),
+		Timestamp:      time.Now().UnixMilli(),
+		Source: &webhook.Source{
+			Type:   webhook.SourceTypeUser,
+			UserID: user.UserID,
+		},
+		ReplyToken: lineid.NewReplyToken(),
+		Mode:       webhook.ModeActive,
+	}
+
+	if err := webhook.Enqueue(ctx, s.db, bot.ID, s.now(), event); err != nil {
+		return adminapi.SendUserMessage500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to queue webhook event: %v", err))), nil
+	}
+
+	content, err := json.Marshal(message)
+	if err != nil {
+		return adminapi.SendUserMessage500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to serialize message: %v", err))), nil
+	}
+	// Bots in auto mode read messages as soon as they receive them
+	var readAt pgtype.Timestamptz
+	if bot.MarkAsReadMode == "auto" {
+		readAt = pgtype.Timestamptz{Time: s.now(), Valid: true}
+	}
+	if _, err := s.db.CreateConversationMessages(ctx, []db.CreateConversationMessagesParams{{
+		BotID:    bot.ID,
+		ChatType: webhook.SourceTypeUser,
+		ChatID:   user.UserID,
+		Sender:   conversationSenderUser,
+		Content:  content,
+		ReadAt:   readAt,
+	}}); err != nil {
+		return adminapi.SendUserMessage500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to store conversation message: %v", err))), nil
+	}
+
+	return adminapi.SendUserMessage202JSONResponse{
+		WebhookEventId: event.WebhookEventID,
+		MessageId:      message.ID,
+		QuoteToken:     lo.EmptyableToPtr(message.QuoteToken),
+		ReplyToken:     event.ReplyToken,
+	}, nil
+}
+
+// buildUserMessage converts the admin request into the message object of a message event
+// The content of image, video, audio and file messages is returned as well; it is nil for the other types.
+func buildUserMessage(body adminapi.SendUserMessageRequest) (*webhook.Message, *messageContent, error) {
+	message := &webhook.Message{
+		ID:   lineid.NewMessageID(),
+		Type: string(body.Type),
+	}
+	// Audio and file messages can't be quoted
+	if body.Type != adminapi.Audio && body.Type != adminapi.File {
+		message.QuoteToken = lineid.NewQuoteToken()
+	}
+
+	switch body.Type {
+	case adminapi.Text:
+		if body.Text == nil || *body.Text == "" {
+			return nil, nil, fmt.Errorf("text is required for text messages")
+		}
+		if len([]rune(*body.Text)) > 5000 {
+			return nil, nil, fmt.Errorf("text must be 5000 characters or less")
+		}
+		message.Text = *body.Text
+	case adminapi.Sticker:
+		if body.PackageId == nil || *body.PackageId == "" || body.StickerId == nil || *body.StickerId == "" {
+			return nil, nil, fmt.Errorf("packageId and stickerId are required for sticker messages")
+		}
+		message.PackageID = *body.PackageId
+		message.StickerID = *body.StickerId
+		message.StickerResourceType = "STATIC"
+	case adminapi.Image, adminapi.Video, adminapi.Audio:
+		if body.Type != adminapi.Image {
+			if body.Duration != nil && *body.Duration < 0 {
+				return nil, nil, fmt.Errorf("duration must not be negative")
+			}
+			message.Duration = lo.FromPtr(body.Duration)
+		}
+		content, err := buildMessageContent(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		message.ContentProvider = &webhook.ContentProvider{Type: webhook.ContentProviderTypeLine}
+		return message, content, nil
+	case adminapi.File:
+		if body.FileName == nil || *body.FileName == "" {
+			return nil, nil, fmt.Errorf("fileName is required for file messages")
+		}
+		content, err := buildMessageContent(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		message.FileName = *body.FileName
+		message.FileSize = int64(len(content.data))
+		return message, content, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported message type: %s", body.Type)
+	}
+
+	return message, nil, nil
+}
@@ -0,0 +1,218 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestSendUserMessage(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	dispatcher := webhook.NewDispatcher(dbClient, webhook.NewClient())
+	ctx := context.Background()
+
+	// Create a bot
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	// Create a user
+	user, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_sender",
+		DisplayName: "Sender",
+	})
+	require.NoError(t, err)
+
+	// Start a bot server which records received webhooks
+	received := make(chan webhook.Payload, 10)
+	botServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.Header.Get("X-Line-Signature") != webhook.Sign(createdBot.ChannelSecret, body) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var payload webhook.Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer botServer.Close()
+
+	_, err = srv.SetWebhookEndpoint(botCtx, messagingapi.SetWebhookEndpointRequestObject{
+		Body: &messagingapi.SetWebhookEndpointJSONRequestBody{
+			Endpoint: botServer.URL,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("delivers text message event", func(t *testing.T) {
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello, bot"),
+			},
+		})
+		require.NoError(t, err)
+
+		sendResp, ok := resp.(adminapi.SendUserMessage202JSONResponse)
+		require.True(t, ok, "Expected SendUserMessage202JSONResponse, got %T", resp)
+		assert.NotEmpty(t, sendResp.WebhookEventId)
+		assert.NotEmpty(t, sendResp.MessageId)
+		assert.NotEmpty(t, lo.FromPtr(sendResp.QuoteToken))
+		assert.NotEmpty(t, sendResp.ReplyToken)
+
+		processed, err := dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+
+		payload := <-received
+		assert.Equal(t, createdBot.UserId, payload.Destination)
+		require.Len(t, payload.Events, 1)
+
+		event := payload.Events[0]
+		assert.Equal(t, "message", event.Type)
+		assert.Equal(t, "active", event.Mode)
+		assert.Equal(t, sendResp.WebhookEventId, event.WebhookEventID)
+		assert.Equal(t, sendResp.ReplyToken, event.ReplyToken)
+		assert.False(t, event.DeliveryContext.IsRedelivery)
+		assert.NotZero(t, event.Timestamp)
+		require.NotNil(t, event.Source)
+		assert.Equal(t, "user", event.Source.Type)
+		assert.Equal(t, user.UserID, event.Source.UserID)
+		require.NotNil(t, event.Message)
+		assert.Equal(t, "text", event.Message.Type)
+		assert.Equal(t, sendResp.MessageId, event.Message.ID)
+		assert.Equal(t, lo.FromPtr(sendResp.QuoteToken), event.Message.QuoteToken)
+		assert.Equal(t, "Hello, bot", event.Message.Text)
+	})
+
+	t.Run("delivers sticker message event", func(t *testing.T) {
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type:      adminapi.Sticker,
+				PackageId: lo.ToPtr("446"),
+				StickerId: lo.ToPtr("1988"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.SendUserMessage202JSONResponse)
+		require.True(t, ok, "Expected SendUserMessage202JSONResponse, got %T", resp)
+
+		_, err = dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+
+		payload := <-received
+		require.Len(t, payload.Events, 1)
+		require.NotNil(t, payload.Events[0].Message)
+		assert.Equal(t, "sticker", payload.Events[0].Message.Type)
+		assert.Equal(t, "446", payload.Events[0].Message.PackageID)
+		assert.Equal(t, "1988", payload.Events[0].Message.StickerID)
+	})
+
+	t.Run("returns 400 for text message without text", func(t *testing.T) {
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+			},
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.SendUserMessage400JSONResponse)
+		assert.True(t, ok, "Expected 400 response, got %T", resp)
+	})
+
+	t.Run("returns 404 for non-existent user", func(t *testing.T) {
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: "U_nonexistent",
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.SendUserMessage404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+
+	t.Run("returns 404 for non-existent bot", func(t *testing.T) {
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  "U_nonexistent_bot",
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.SendUserMessage404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+
+	t.Run("marks event as failed when no webhook is configured", func(t *testing.T) {
+		otherResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+			Body: &adminapi.CreateBotRequest{
+				DisplayName: "Bot Without Webhook",
+			},
+		})
+		require.NoError(t, err)
+		otherBot, ok := otherResp.(adminapi.CreateBot201JSONResponse)
+		require.True(t, ok)
+
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  otherBot.UserId,
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello"),
+			},
+		})
+		require.NoError(t, err)
+
+		sendResp, ok := resp.(adminapi.SendUserMessage202JSONResponse)
+		require.True(t, ok, "Expected SendUserMessage202JSONResponse, got %T", resp)
+
+		_, err = dispatcher.ProcessPending(ctx)
+		require.NoError(t, err)
+
+		event, err := dbClient.GetWebhookEventByWebhookEventID(ctx, sendResp.WebhookEventId)
+		require.NoError(t, err)
+		assert.Equal(t, "failed", event.Status)
+		assert.Equal(t, "No webhook configured", lo.FromPtr(event.LastError))
+	})
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+// GetApiCall returns the Messaging API request and response recorded with the request ID
+func (s *server) GetApiCall(ctx context.Context, request adminapi.GetApiCallRequestObject) (adminapi.GetApiCallResponseObject, error) {
+	call, err := s.db.GetApiCallByRequestID(ctx, request.RequestId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.GetApiCall404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("API call with request ID %s not found", request.RequestId))), nil
+		}
+		return adminapi.GetApiCall500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get API call: %v", err))), nil
+	}
+
+	return adminapi.GetApiCall200JSONResponse(buildApiCall(call)), nil
+}
+
+// buildApiCall converts a database API call to an API response
+func buildApiCall(call db.ApiCall) adminapi.ApiCall {
+	headers := func(b []byte) map[string]string {
+		var h map[string]string
+		if err := json.Unmarshal(b, &h); err != nil {
+			return map[string]string{}
+		}
+		return h
+	}
+
+	return adminapi.ApiCall{
+		RequestId:       call.RequestID,
+		Method:          call.Method,
+		Path:            call.Path,
+		RequestHeaders:  headers(call.RequestHeaders),
+		RequestBody:     call.RequestBody,
+		StatusCode:      int(call.StatusCode),
+		ResponseHeaders: headers(call.ResponseHeaders),
+		ResponseBody:    call.ResponseBody,
+		LatencyMs:       int(call.LatencyMs),
+		CreatedAt:       call.CreatedAt.Time,
+	}
+}
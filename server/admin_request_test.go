@@ -0,0 +1,54 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestGetApiCall(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	_, err := dbClient.CreateApiCall(ctx, db.CreateApiCallParams{
+		RequestID:       "request-1",
+		Method:          "POST",
+		Path:            "/v2/bot/message/push",
+		RequestHeaders:  []byte(`{"Content-Type":"application/json"}`),
+		RequestBody:     lo.ToPtr(`{"to":"U123","messages":[]}`),
+		StatusCode:      400,
+		ResponseHeaders: []byte(`{"X-Line-Request-Id":"request-1"}`),
+		ResponseBody:    lo.ToPtr(`{"message":"The request body has 1 error(s)"}`),
+		LatencyMs:       3,
+	})
+	require.NoError(t, err)
+
+	t.Run("returns the request and response", func(t *testing.T) {
+		resp, err := srv.GetApiCall(ctx, adminapi.GetApiCallRequestObject{RequestId: "request-1"})
+		require.NoError(t, err)
+		call, ok := resp.(adminapi.GetApiCall200JSONResponse)
+		require.True(t, ok, "Expected GetApiCall200JSONResponse, got %T", resp)
+		assert.Equal(t, "request-1", call.RequestId)
+		assert.Equal(t, "POST", call.Method)
+		assert.Equal(t, "/v2/bot/message/push", call.Path)
+		assert.Equal(t, map[string]string{"Content-Type": "application/json"}, call.RequestHeaders)
+		assert.Equal(t, `{"to":"U123","messages":[]}`, lo.FromPtr(call.RequestBody))
+		assert.Equal(t, 400, call.StatusCode)
+		assert.Equal(t, map[string]string{"X-Line-Request-Id": "request-1"}, call.ResponseHeaders)
+		assert.Equal(t, `{"message":"The request body has 1 error(s)"}`, lo.FromPtr(call.ResponseBody))
+		assert.Equal(t, 3, call.LatencyMs)
+	})
+
+	t.Run("returns not found for an unknown request ID", func(t *testing.T) {
+		resp, err := srv.GetApiCall(ctx, adminapi.GetApiCallRequestObject{RequestId: "unknown"})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.GetApiCall404JSONResponse{}, resp)
+	})
+}
@@ -37,6 +37,8 @@ func TestCreateBot(t *testing.T) {
 		assert.NotEmpty(t, botResp.UserId)
 		assert.Equal(t, adminapi.BotInfoResponseChatModeBot, botResp.ChatMode)
 		assert.Equal(t, adminapi.BotInfoResponseMarkAsReadModeManual, botResp.MarkAsReadMode)
+		assert.Len(t, botResp.ChannelSecret, 32)
+		assert.False(t, botResp.WebhookRedelivery)
 	})
 
 	t.Run("create bot with all fields", func(t *testing.T) {
@@ -45,18 +47,21 @@ func TestCreateBot(t *testing.T) {
 		displayName := "Full Test Bot"
 		pictureURL := "https://example.com/picture.jpg"
 		premiumID := "premium123"
+		channelSecret := "0123456789abcdef0123456789abcdef"
 		chatMode := adminapi.CreateBotRequestChatModeChat
 		markAsReadMode := adminapi.CreateBotRequestMarkAsReadModeAuto
 
 		req := adminapi.CreateBotRequestObject{
 			Body: &adminapi.CreateBotRequest{
-				UserId:         &userID,
-				BasicId:        &basicID,
-				DisplayName:    displayName,
-				PictureUrl:     &pictureURL,
-				PremiumId:      &premiumID,
-				ChatMode:       &chatMode,
-				MarkAsReadMode: &markAsReadMode,
+				UserId:            &userID,
+				BasicId:           &basicID,
+				DisplayName:       displayName,
+				PictureUrl:        &pictureURL,
+				PremiumId:         &premiumID,
+				ChannelSecret:     &channelSecret,
+				ChatMode:          &chatMode,
+				MarkAsReadMode:    &markAsReadMode,
+				WebhookRedelivery: lo.ToPtr(true),
 			},
 		}
 
@@ -70,8 +75,10 @@ func TestCreateBot(t *testing.T) {
 		assert.Equal(t, displayName, botResp.DisplayName)
 		assert.Equal(t, &pictureURL, botResp.PictureUrl)
 		assert.Equal(t, &premiumID, botResp.PremiumId)
+		assert.Equal(t, channelSecret, botResp.ChannelSecret)
 		assert.Equal(t, adminapi.BotInfoResponseChatModeChat, botResp.ChatMode)
 		assert.Equal(t, adminapi.BotInfoResponseMarkAsReadModeAuto, botResp.MarkAsReadMode)
+		assert.True(t, botResp.WebhookRedelivery)
 	})
 
 	t.Run("create duplicate bot returns conflict", func(t *testing.T) {
@@ -98,6 +105,56 @@ func TestCreateBot(t *testing.T) {
 	})
 }
 
+func TestSetWebhookRedelivery(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	t.Run("enable and disable redelivery", func(t *testing.T) {
+		resp, err := srv.SetWebhookRedelivery(ctx, adminapi.SetWebhookRedeliveryRequestObject{
+			BotId: createdBot.UserId,
+			Body:  &adminapi.SetWebhookRedeliveryRequest{Enabled: true},
+		})
+		require.NoError(t, err)
+		botResp, ok := resp.(adminapi.SetWebhookRedelivery200JSONResponse)
+		require.True(t, ok, "Expected SetWebhookRedelivery200JSONResponse, got %T", resp)
+		assert.True(t, botResp.WebhookRedelivery)
+
+		bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+		require.NoError(t, err)
+		assert.True(t, bot.WebhookRedelivery)
+
+		resp, err = srv.SetWebhookRedelivery(ctx, adminapi.SetWebhookRedeliveryRequestObject{
+			BotId: createdBot.UserId,
+			Body:  &adminapi.SetWebhookRedeliveryRequest{Enabled: false},
+		})
+		require.NoError(t, err)
+		botResp, ok = resp.(adminapi.SetWebhookRedelivery200JSONResponse)
+		require.True(t, ok, "Expected SetWebhookRedelivery200JSONResponse, got %T", resp)
+		assert.False(t, botResp.WebhookRedelivery)
+	})
+
+	t.Run("non-existent bot returns not found", func(t *testing.T) {
+		resp, err := srv.SetWebhookRedelivery(ctx, adminapi.SetWebhookRedeliveryRequestObject{
+			BotId: "U_nonexistent",
+			Body:  &adminapi.SetWebhookRedeliveryRequest{Enabled: true},
+		})
+		require.NoError(t, err)
+		_, ok := resp.(adminapi.SetWebhookRedelivery404JSONResponse)
+		assert.True(t, ok, "Expected SetWebhookRedelivery404JSONResponse, got %T", resp)
+	})
+}
+
 func TestCreateFollowers(t *testing.T) {
 	dbClient := db.NewTestDB(t)
 	srv := server.New(dbClient)
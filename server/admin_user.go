@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/pgutil"
+)
+
+// SetUserAttributes replaces the demographic attributes narrowcast filters are evaluated against
+func (s *server) SetUserAttributes(ctx context.Context, request adminapi.SetUserAttributesRequestObject) (adminapi.SetUserAttributesResponseObject, error) {
+	if request.Body == nil {
+		return adminapi.SetUserAttributes400JSONResponse(newAdminError("INVALID_REQUEST", "Request body is required")), nil
+	}
+
+	body := request.Body
+	if body.Gender != nil && !slices.Contains(demographicGenders, string(*body.Gender)) {
+		return adminapi.SetUserAttributes400JSONResponse(newAdminError("INVALID_REQUEST", fmt.Sprintf("Unsupported gender: %s", *body.Gender))), nil
+	}
+	if body.AppType != nil && !slices.Contains(demographicAppTypes, string(*body.AppType)) {
+		return adminapi.SetUserAttributes400JSONResponse(newAdminError("INVALID_REQUEST", fmt.Sprintf("Unsupported app type: %s", *body.AppType))), nil
+	}
+	if body.Area != nil && !slices.Contains(demographicAreas, *body.Area) {
+		return adminapi.SetUserAttributes400JSONResponse(newAdminError("INVALID_REQUEST", fmt.Sprintf("Unsupported area: %s", *body.Area))), nil
+	}
+	if body.Age != nil && *body.Age < 0 {
+		return adminapi.SetUserAttributes400JSONResponse(newAdminError("INVALID_REQUEST", "Age must be 0 or greater")), nil
+	}
+
+	params := db.UpdateUserAttributesParams{
+		Gender:  (*string)(body.Gender),
+		AppType: (*string)(body.AppType),
+		Area:    body.Area,
+		UserID:  request.UserId,
+	}
+	if body.Age != nil {
+		params.Age = lo.ToPtr(int32(*body.Age))
+	}
+	user, err := s.db.UpdateUserAttributes(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.SetUserAttributes404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("User with user ID %s not found", request.UserId))), nil
+		}
+		return adminapi.SetUserAttributes500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to update user attributes: %v", err))), nil
+	}
+
+	resp := adminapi.SetUserAttributes200JSONResponse{
+		UserId:  user.UserID,
+		Gender:  (*adminapi.UserAttributesResponseGender)(user.Gender),
+		AppType: (*adminapi.UserAttributesResponseAppType)(user.AppType),
+		Area:    user.Area,
+	}
+	if user.Age != nil {
+		resp.Age = lo.ToPtr(int(*user.Age))
+	}
+	return resp, nil
+}
+
+// SetUserPhoneNumber registers the phone number a user receives PNP messages at
+func (s *server) SetUserPhoneNumber(ctx context.Context, request adminapi.SetUserPhoneNumberRequestObject) (adminapi.SetUserPhoneNumberResponseObject, error) {
+	if request.Body == nil {
+		return adminapi.SetUserPhoneNumber400JSONResponse(newAdminError("INVALID_REQUEST", "Request body is required")), nil
+	}
+	if !e164Pattern.MatchString(request.Body.PhoneNumber) {
+		return adminapi.SetUserPhoneNumber400JSONResponse(newAdminError("INVALID_REQUEST", fmt.Sprintf("Phone number must be in E.164 format: %s", request.Body.PhoneNumber))), nil
+	}
+
+	user, err := s.db.GetUser(ctx, request.UserId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.SetUserPhoneNumber404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("User with user ID %s not found", request.UserId))), nil
+		}
+		return adminapi.SetUserPhoneNumber500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get user: %v", err))), nil
+	}
+
+	phoneNumber, err := s.db.UpsertUserPhoneNumber(ctx, db.UpsertUserPhoneNumberParams{
+		UserID:          user.ID,
+		PhoneNumber:     request.Body.PhoneNumber,
+		PhoneNumberHash: hashPhoneNumber(request.Body.PhoneNumber),
+	})
+	if err != nil {
+		if pgutil.IsUniqueViolationError(err) {
+			return adminapi.SetUserPhoneNumber409JSONResponse(newAdminError("CONFLICT", fmt.Sprintf("Phone number %s is registered to another user", request.Body.PhoneNumber))), nil
+		}
+		return adminapi.SetUserPhoneNumber500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to set phone number: %v", err))), nil
+	}
+
+	return adminapi.SetUserPhoneNumber200JSONResponse{
+		UserId:          user.UserID,
+		PhoneNumber:     phoneNumber.PhoneNumber,
+		PhoneNumberHash: phoneNumber.PhoneNumberHash,
+	}, nil
+}
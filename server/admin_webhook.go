@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+)
+
+const defaultWebhookDeliveryLimit = 100
+
+// ListWebhookDeliveries lists the webhook requests sent to a bot, newest first
+func (s *server) ListWebhookDeliveries(ctx context.Context, request adminapi.ListWebhookDeliveriesRequestObject) (adminapi.ListWebhookDeliveriesResponseObject, error) {
+	limit := defaultWebhookDeliveryLimit
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+	if limit < 1 || limit > 1000 {
+		return adminapi.ListWebhookDeliveries400JSONResponse(newAdminError("INVALID_REQUEST", "limit must be between 1 and 1000")), nil
+	}
+
+	bot, err := s.db.GetBotByUserID(ctx, request.BotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.ListWebhookDeliveries404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.ListWebhookDeliveries500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))), nil
+	}
+
+	deliveries, err := s.db.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		BotID:          bot.ID,
+		WebhookEventID: request.Params.WebhookEventId,
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return adminapi.ListWebhookDeliveries500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to list webhook deliveries: %v", err))), nil
+	}
+
+	response := adminapi.ListWebhookDeliveries200JSONResponse{
+		Deliveries: make([]adminapi.WebhookDelivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, buildWebhookDelivery(delivery))
+	}
+	return response, nil
+}
+
+// GetWebhookDelivery gets a webhook request sent to a bot and the bot server's response
+func (s *server) GetWebhookDelivery(ctx context.Context, request adminapi.GetWebhookDeliveryRequestObject) (adminapi.GetWebhookDeliveryResponseObject, error) {
+	bot, err := s.db.GetBotByUserID(ctx, request.BotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.GetWebhookDelivery404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Bot with user ID %s not found", request.BotId))), nil
+		}
+		return adminapi.GetWebhookDelivery500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get bot: %v", err))), nil
+	}
+
+	delivery, err := s.db.GetWebhookDelivery(ctx, db.GetWebhookDeliveryParams{
+		ID:    request.DeliveryId,
+		BotID: bot.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return adminapi.GetWebhookDelivery404JSONResponse(newAdminError("NOT_FOUND", fmt.Sprintf("Webhook delivery %d not found", request.DeliveryId))), nil
+		}
+		return adminapi.GetWebhookDelivery500JSONResponse(newAdminError("INTERNAL_ERROR", fmt.Sprintf("Failed to get webhook delivery: %v", err))), nil
+	}
+
+	return adminapi.GetWebhookDelivery200JSONResponse(buildWebhookDelivery(delivery)), nil
+}
+
+// buildWebhookDelivery converts a database webhook delivery to an API response
+func buildWebhookDelivery(delivery db.WebhookDelivery) adminapi.WebhookDelivery {
+	var requestHeaders map[string]string
+	if err := json.Unmarshal(delivery.RequestHeaders, &requestHeaders); err != nil {
+		requestHeaders = map[string]string{}
+	}
+
+	statusCode := int(lo.FromPtr(delivery.StatusCode))
+	return adminapi.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookEventId: delivery.WebhookEventID,
+		Endpoint:       delivery.Endpoint,
+		RequestHeaders: requestHeaders,
+		RequestBody:    delivery.RequestBody,
+		StatusCode:     statusCode,
+		ResponseBody:   delivery.ResponseBody,
+		LatencyMs:      int(delivery.LatencyMs),
+		Success:        delivery.Error == nil && statusCode >= 200 && statusCode < 300,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt.Time,
+	}
+}
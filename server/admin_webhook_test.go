@@ -0,0 +1,203 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	dispatcher := webhook.NewDispatcher(dbClient, webhook.NewClient())
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	user, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_sender",
+		DisplayName: "Sender",
+	})
+	require.NoError(t, err)
+
+	botServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid signature"}`))
+	}))
+	defer botServer.Close()
+
+	_, err = srv.SetWebhookEndpoint(botCtx, messagingapi.SetWebhookEndpointRequestObject{
+		Body: &messagingapi.SetWebhookEndpointJSONRequestBody{
+			Endpoint: botServer.URL,
+		},
+	})
+	require.NoError(t, err)
+
+	// Record a test request and a delivery of a queued event
+	_, err = srv.TestWebhookEndpoint(botCtx, messagingapi.TestWebhookEndpointRequestObject{})
+	require.NoError(t, err)
+
+	sendResp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+		BotId:  createdBot.UserId,
+		UserId: user.UserID,
+		Body: &adminapi.SendUserMessageRequest{
+			Type: adminapi.Text,
+			Text: lo.ToPtr("Hello, bot"),
+		},
+	})
+	require.NoError(t, err)
+	sent, ok := sendResp.(adminapi.SendUserMessage202JSONResponse)
+	require.True(t, ok)
+
+	_, err = dispatcher.ProcessPending(ctx)
+	require.NoError(t, err)
+
+	t.Run("lists deliveries newest first", func(t *testing.T) {
+		resp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: createdBot.UserId,
+		})
+		require.NoError(t, err)
+
+		listResp, ok := resp.(adminapi.ListWebhookDeliveries200JSONResponse)
+		require.True(t, ok, "Expected ListWebhookDeliveries200JSONResponse, got %T", resp)
+		require.Len(t, listResp.Deliveries, 2)
+
+		eventDelivery := listResp.Deliveries[0]
+		assert.Equal(t, &sent.WebhookEventId, eventDelivery.WebhookEventId)
+		assert.Equal(t, botServer.URL, eventDelivery.Endpoint)
+		assert.Equal(t, http.StatusBadRequest, eventDelivery.StatusCode)
+		assert.False(t, eventDelivery.Success)
+		assert.Equal(t, `{"message":"invalid signature"}`, lo.FromPtr(eventDelivery.ResponseBody))
+		assert.Equal(t, "Bot server responded with 400 Bad Request", lo.FromPtr(eventDelivery.Error))
+		assert.Contains(t, eventDelivery.RequestBody, "Hello, bot")
+		assert.Equal(t, webhook.Sign(createdBot.ChannelSecret, []byte(eventDelivery.RequestBody)), eventDelivery.RequestHeaders["X-Line-Signature"])
+		assert.Equal(t, "application/json", eventDelivery.RequestHeaders["Content-Type"])
+
+		testDelivery := listResp.Deliveries[1]
+		assert.Nil(t, testDelivery.WebhookEventId)
+		assert.Equal(t, http.StatusBadRequest, testDelivery.StatusCode)
+	})
+
+	t.Run("filters deliveries by webhook event ID", func(t *testing.T) {
+		resp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: createdBot.UserId,
+			Params: adminapi.ListWebhookDeliveriesParams{
+				WebhookEventId: &sent.WebhookEventId,
+			},
+		})
+		require.NoError(t, err)
+
+		listResp, ok := resp.(adminapi.ListWebhookDeliveries200JSONResponse)
+		require.True(t, ok, "Expected ListWebhookDeliveries200JSONResponse, got %T", resp)
+		require.Len(t, listResp.Deliveries, 1)
+		assert.Equal(t, &sent.WebhookEventId, listResp.Deliveries[0].WebhookEventId)
+	})
+
+	t.Run("limits the number of deliveries", func(t *testing.T) {
+		resp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: createdBot.UserId,
+			Params: adminapi.ListWebhookDeliveriesParams{
+				Limit: lo.ToPtr(1),
+			},
+		})
+		require.NoError(t, err)
+
+		listResp, ok := resp.(adminapi.ListWebhookDeliveries200JSONResponse)
+		require.True(t, ok, "Expected ListWebhookDeliveries200JSONResponse, got %T", resp)
+		assert.Len(t, listResp.Deliveries, 1)
+	})
+
+	t.Run("returns 400 for invalid limit", func(t *testing.T) {
+		resp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: createdBot.UserId,
+			Params: adminapi.ListWebhookDeliveriesParams{
+				Limit: lo.ToPtr(0),
+			},
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.ListWebhookDeliveries400JSONResponse)
+		assert.True(t, ok, "Expected 400 response, got %T", resp)
+	})
+
+	t.Run("returns 404 for non-existent bot", func(t *testing.T) {
+		resp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: "U_nonexistent",
+		})
+		require.NoError(t, err)
+
+		_, ok := resp.(adminapi.ListWebhookDeliveries404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+
+	t.Run("gets a delivery", func(t *testing.T) {
+		listResp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: createdBot.UserId,
+		})
+		require.NoError(t, err)
+		deliveries := listResp.(adminapi.ListWebhookDeliveries200JSONResponse).Deliveries
+		require.NotEmpty(t, deliveries)
+
+		resp, err := srv.GetWebhookDelivery(ctx, adminapi.GetWebhookDeliveryRequestObject{
+			BotId:      createdBot.UserId,
+			DeliveryId: deliveries[0].Id,
+		})
+		require.NoError(t, err)
+
+		getResp, ok := resp.(adminapi.GetWebhookDelivery200JSONResponse)
+		require.True(t, ok, "Expected GetWebhookDelivery200JSONResponse, got %T", resp)
+		assert.Equal(t, deliveries[0].Id, getResp.Id)
+		assert.Equal(t, deliveries[0].RequestBody, getResp.RequestBody)
+	})
+
+	t.Run("returns 404 for delivery of another bot", func(t *testing.T) {
+		otherResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+			Body: &adminapi.CreateBotRequest{
+				DisplayName: "Other Bot",
+			},
+		})
+		require.NoError(t, err)
+		otherBot, ok := otherResp.(adminapi.CreateBot201JSONResponse)
+		require.True(t, ok)
+
+		listResp, err := srv.ListWebhookDeliveries(ctx, adminapi.ListWebhookDeliveriesRequestObject{
+			BotId: createdBot.UserId,
+		})
+		require.NoError(t, err)
+		deliveries := listResp.(adminapi.ListWebhookDeliveries200JSONResponse).Deliveries
+		require.NotEmpty(t, deliveries)
+
+		resp, err := srv.GetWebhookDelivery(ctx, adminapi.GetWebhookDeliveryRequestObject{
+			BotId:      otherBot.UserId,
+			DeliveryId: deliveries[0].Id,
+		})
+		require.NoError(t, err)
+
+		_, ok = resp.(adminapi.GetWebhookDelivery404JSONResponse)
+		assert.True(t, ok, "Expected 404 response, got %T", resp)
+	})
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+)
+
+const (
+	// maxAggregationUnitsPerMonth is the number of distinct aggregation units a bot can use in a calendar month
+	maxAggregationUnitsPerMonth         = 1000
+	defaultAggregationUnitNameListLimit = 100
+)
+
+// aggregationUnitNamePattern matches the names of custom aggregation units: up to 30 half-width alphanumerics and underscores
+var aggregationUnitNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]{1,30}$`)
+
+// aggregationUnitMonth returns the range of the calendar month (UTC+9) of now, which aggregation units are counted in
+func aggregationUnitMonth(now time.Time) (pgtype.Timestamptz, pgtype.Timestamptz) {
+	month := startOfMonth(now)
+	return pgtype.Timestamptz{Time: month, Valid: true}, pgtype.Timestamptz{Time: month.AddDate(0, 1, 0), Valid: true}
+}
+
+// validateAggregationUnits checks the custom aggregation units of a push or multicast request.
+// A message can have one aggregation unit, and a new unit can't be used once the bot has used 1,000 units in the month.
+func (s *server) validateAggregationUnits(ctx context.Context, botID int32, units *[]string) error {
+	if units == nil || len(*units) == 0 {
+		return nil
+	}
+
+	v := newObjectValidator()
+	if len(*units) > 1 {
+		v.fail("customAggregationUnits", "Size must be between 0 and 1")
+		return v.result()
+	}
+	name := (*units)[0]
+	if !aggregationUnitNamePattern.MatchString(name) {
+		v.fail(indexPath("customAggregationUnits", 0), "must be 1 to 30 characters of half-width alphanumerics and underscores")
+		return v.result()
+	}
+
+	usedFrom, usedUntil := aggregationUnitMonth(s.now())
+	used, err := s.db.IsBotAggregationUnitUsed(ctx, db.IsBotAggregationUnitUsedParams{
+		BotID:     botID,
+		Name:      name,
+		UsedFrom:  usedFrom,
+		UsedUntil: usedUntil,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check aggregation unit: %w", err)
+	}
+	if used {
+		return nil
+	}
+	count, err := s.db.CountBotAggregationUnits(ctx, db.CountBotAggregationUnitsParams{
+		BotID:     botID,
+		UsedFrom:  usedFrom,
+		UsedUntil: usedUntil,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count aggregation units: %w", err)
+	}
+	if count >= maxAggregationUnitsPerMonth {
+		v.fail(indexPath("customAggregationUnits", 0), fmt.Sprintf("The number of aggregation units used in a month must be %d or less", maxAggregationUnitsPerMonth))
+		return v.result()
+	}
+	return nil
+}
+
+// createAggregationUnits records the custom aggregation units the message was sent with
+func (s *server) createAggregationUnits(ctx context.Context, msg db.Message, units *[]string) error {
+	if units == nil {
+		return nil
+	}
+	for _, name := range *units {
+		if err := s.db.CreateMessageAggregationUnit(ctx, db.CreateMessageAggregationUnitParams{
+			MessageID: msg.ID,
+			BotID:     msg.BotID,
+			Name:      name,
+			CreatedAt: msg.CreatedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to store aggregation unit: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetAggregationUnitUsage gets aggregation unit usage
+func (s *server) GetAggregationUnitUsage(ctx context.Context, request messagingapi.GetAggregationUnitUsageRequestObject) (messagingapi.GetAggregationUnitUsageResponseObject, error) {
+	usedFrom, usedUntil := aggregationUnitMonth(s.now())
+	count, err := s.db.CountBotAggregationUnits(ctx, db.CountBotAggregationUnitsParams{
+		BotID:     auth.GetBotID(ctx),
+		UsedFrom:  usedFrom,
+		UsedUntil: usedUntil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count aggregation units: %w", err)
+	}
+	return messagingapi.GetAggregationUnitUsage200JSONResponse{NumOfCustomAggregationUnits: count}, nil
+}
+
+// GetAggregationUnitNameList gets the list of aggregation unit names used this month, in the order they were first used
+func (s *server) GetAggregationUnitNameList(ctx context.Context, request messagingapi.GetAggregationUnitNameListRequestObject) (messagingapi.GetAggregationUnitNameListResponseObject, error) {
+	limit := int32(defaultAggregationUnitNameListLimit)
+	if request.Params.Limit != nil {
+		parsed, err := strconv.Atoi(*request.Params.Limit)
+		if err != nil || parsed < 1 || parsed > defaultAggregationUnitNameListLimit {
+			return nil, NewValidationError(fmt.Sprintf("The value for the 'limit' parameter must be between 1 and %d", defaultAggregationUnitNameListLimit))
+		}
+		limit = int32(parsed)
+	}
+
+	// The start token is the offset of the next name
+	offset := int32(0)
+	if request.Params.Start != nil && *request.Params.Start != "" {
+		parsed, err := strconv.Atoi(*request.Params.Start)
+		if err != nil || parsed < 0 {
+			return nil, NewValidationError("The value for the 'start' parameter is invalid")
+		}
+		offset = int32(parsed)
+	}
+
+	usedFrom, usedUntil := aggregationUnitMonth(s.now())
+	names, err := s.db.ListBotAggregationUnitNames(ctx, db.ListBotAggregationUnitNamesParams{
+		BotID:     auth.GetBotID(ctx),
+		UsedFrom:  usedFrom,
+		UsedUntil: usedUntil,
+		MaxCount:  limit + 1, // Get one extra to check if there are more
+		SkipCount: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aggregation units: %w", err)
+	}
+
+	response := messagingapi.GetAggregationUnitNameList200JSONResponse{
+		CustomAggregationUnits: names,
+	}
+	if int32(len(names)) > limit {
+		response.CustomAggregationUnits = names[:limit]
+		response.Next = lo.ToPtr(strconv.Itoa(int(offset + limit)))
+	}
+	return response, nil
+}
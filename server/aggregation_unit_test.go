@@ -0,0 +1,152 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestAggregationUnits(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	// The messages are sent just before the end of the month in UTC+9, which aggregation units are counted in
+	sentAt := time.Date(2024, 6, 30, 23, 30, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+	srv := server.New(dbClient, server.WithClock(func() time.Time { return sentAt }))
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	for _, userID := range []string{"U_alice", "U_bob"} {
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+		_, err = srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: userID,
+		})
+		require.NoError(t, err)
+	}
+
+	textMessage := []map[string]any{{"type": "text", "text": "Hello"}}
+	push := func(t *testing.T, units ...string) error {
+		t.Helper()
+		_, err := srv.PushMessage(withRequestBody(t, botCtx, map[string]any{"to": "U_alice", "messages": textMessage, "customAggregationUnits": units}), messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To:                     "U_alice",
+				Messages:               []messagingapi.Message{{Type: "text"}},
+				CustomAggregationUnits: &units,
+			},
+		})
+		return err
+	}
+	usage := func(t *testing.T) int64 {
+		t.Helper()
+		resp, err := srv.GetAggregationUnitUsage(botCtx, messagingapi.GetAggregationUnitUsageRequestObject{})
+		require.NoError(t, err)
+		return resp.(messagingapi.GetAggregationUnitUsage200JSONResponse).NumOfCustomAggregationUnits
+	}
+	nameList := func(t *testing.T, params messagingapi.GetAggregationUnitNameListParams) messagingapi.GetAggregationUnitNameList200JSONResponse {
+		t.Helper()
+		resp, err := srv.GetAggregationUnitNameList(botCtx, messagingapi.GetAggregationUnitNameListRequestObject{Params: params})
+		require.NoError(t, err)
+		list, ok := resp.(messagingapi.GetAggregationUnitNameList200JSONResponse)
+		require.True(t, ok, "Expected GetAggregationUnitNameList200JSONResponse, got %T", resp)
+		return list
+	}
+
+	t.Run("counts the distinct aggregation units used this month", func(t *testing.T) {
+		require.NoError(t, push(t, "campaign_a"))
+		require.NoError(t, push(t, "campaign_a"))
+		require.NoError(t, push(t, "Campaign_A"))
+
+		multicastTo := []string{"U_alice", "U_bob"}
+		units := []string{"campaign_b"}
+		_, err := srv.Multicast(withRequestBody(t, botCtx, map[string]any{"to": multicastTo, "messages": textMessage, "customAggregationUnits": units}), messagingapi.MulticastRequestObject{
+			Body: &messagingapi.MulticastRequest{
+				To:                     multicastTo,
+				Messages:               []messagingapi.Message{{Type: "text"}},
+				CustomAggregationUnits: &units,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(3), usage(t))
+	})
+
+	t.Run("lists the names with pagination", func(t *testing.T) {
+		assert.Equal(t, []string{"campaign_a", "Campaign_A", "campaign_b"}, nameList(t, messagingapi.GetAggregationUnitNameListParams{}).CustomAggregationUnits)
+
+		first := nameList(t, messagingapi.GetAggregationUnitNameListParams{Limit: lo.ToPtr("2")})
+		assert.Equal(t, []string{"campaign_a", "Campaign_A"}, first.CustomAggregationUnits)
+		require.NotNil(t, first.Next)
+
+		second := nameList(t, messagingapi.GetAggregationUnitNameListParams{Limit: lo.ToPtr("2"), Start: first.Next})
+		assert.Equal(t, []string{"campaign_b"}, second.CustomAggregationUnits)
+		assert.Nil(t, second.Next)
+	})
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		for _, units := range [][]string{{"campaign-a"}, {"あいう"}, {"a_name_longer_than_30_characters"}, {"campaign_a", "campaign_b"}} {
+			err := push(t, units...)
+			var validationErr *server.ValidationError
+			assert.ErrorAs(t, err, &validationErr, "%v", units)
+		}
+	})
+
+	t.Run("rejects a new aggregation unit over 1,000 units a month", func(t *testing.T) {
+		messages, err := dbClient.GetBotMessages(ctx, db.GetBotMessagesParams{BotID: bot.ID, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		for i := int(usage(t)); i < 1000; i++ {
+			require.NoError(t, dbClient.CreateMessageAggregationUnit(ctx, db.CreateMessageAggregationUnitParams{
+				MessageID: messages[0].ID,
+				BotID:     bot.ID,
+				Name:      fmt.Sprintf("unit_%d", i),
+				CreatedAt: messages[0].CreatedAt,
+			}))
+		}
+
+		err = push(t, "one_too_many")
+		var validationErr *server.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "customAggregationUnits[0]", lo.FromPtr(validationErr.Details[0].Property))
+
+		// Units which have already been used this month can still be used
+		assert.NoError(t, push(t, "campaign_a"))
+	})
+
+	t.Run("counts the aggregation units of the next month from zero", func(t *testing.T) {
+		nextMonth := server.New(dbClient, server.WithClock(func() time.Time {
+			return sentAt.Add(time.Hour)
+		}))
+		usageResp, err := nextMonth.GetAggregationUnitUsage(botCtx, messagingapi.GetAggregationUnitUsageRequestObject{})
+		require.NoError(t, err)
+		assert.Equal(t, messagingapi.GetAggregationUnitUsage200JSONResponse{NumOfCustomAggregationUnits: 0}, usageResp)
+
+		listResp, err := nextMonth.GetAggregationUnitNameList(botCtx, messagingapi.GetAggregationUnitNameListRequestObject{})
+		require.NoError(t, err)
+		assert.Empty(t, listResp.(messagingapi.GetAggregationUnitNameList200JSONResponse).CustomAggregationUnits)
+	})
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+)
+
+const (
+	conversationSenderBot  = "bot"
+	conversationSenderUser = "user"
+)
+
+// messageObjects returns the message objects of a send request as they were sent.
+func messageObjects(ctx context.Context, messages []messagingapi.Message) ([]json.RawMessage, error) {
+	if body := rawbody.GetBody(ctx); body != nil {
+		var request struct {
+			Messages []json.RawMessage `json:"messages"`
+		}
+		if err := json.Unmarshal(body, &request); err == nil && len(request.Messages) == len(messages) {
+			return request.Messages, nil
+		}
+	}
+
+	objects := make([]json.RawMessage, 0, len(messages))
+	for _, message := range messages {
+		object, err := json.Marshal(message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize message: %w", err)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// appendToConversations adds the messages sent by the bot to the conversation with each chat
+func (s *server) appendToConversations(ctx context.Context, msg db.Message, chatType string, chatIDs []string, messages []json.RawMessage) error {
+	rows := make([]db.CreateConversationMessagesParams, 0, len(chatIDs)*len(messages))
+	for _, chatID := range chatIDs {
+		for _, message := range messages {
+			rows = append(rows, db.CreateConversationMessagesParams{
+				BotID:     msg.BotID,
+				ChatType:  chatType,
+				ChatID:    chatID,
+				Sender:    conversationSenderBot,
+				MessageID: &msg.ID,
+				Content:   message,
+			})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, err := s.db.CreateConversationMessages(ctx, rows); err != nil {
+		return fmt.Errorf("failed to store conversation messages: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+)
+
+// followingUsers returns the users who follow the bot without blocking it, in the order and without duplicates.
+// Unknown users and users who have blocked the bot are dropped.
+func (s *server) followingUsers(ctx context.Context, botID int32, userIDs []string) ([]string, error) {
+	following, err := s.db.GetFollowingUserIDs(ctx, db.GetFollowingUserIDsParams{
+		BotID:   botID,
+		UserIds: userIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+	isFollowing := lo.Keyify(following)
+	return lo.Uniq(lo.Filter(userIDs, func(userID string, _ int) bool {
+		_, ok := isFollowing[userID]
+		return ok
+	})), nil
+}
+
+// deliver records the delivery of the message to each recipient and adds the message objects to their conversations.
+// Loading animations in the chats with the recipients stop as the message arrives.
+func (s *server) deliver(ctx context.Context, msg db.Message, recipientType string, recipientIDs []string, messages []json.RawMessage) error {
+	if len(recipientIDs) == 0 {
+		return nil
+	}
+
+	deliveries := make([]db.CreateMessageDeliveriesParams, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		deliveries = append(deliveries, db.CreateMessageDeliveriesParams{
+			MessageID:     msg.ID,
+			RecipientType: recipientType,
+			RecipientID:   recipientID,
+		})
+	}
+	if _, err := s.db.CreateMessageDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to store message deliveries: %w", err)
+	}
+
+	if recipientType == webhook.SourceTypeUser {
+		if err := s.db.DeleteLoadingAnimations(ctx, db.DeleteLoadingAnimationsParams{
+			BotID:   msg.BotID,
+			ChatIds: recipientIDs,
+		}); err != nil {
+			return fmt.Errorf("failed to stop loading animations: %w", err)
+		}
+	}
+
+	return s.appendToConversations(ctx, msg, recipientType, recipientIDs, messages)
+}
@@ -0,0 +1,313 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+const (
+	maxAltTextLength   = 1500
+	maxCarouselBubbles = 12
+	maxBubbleSize      = 30 * 1000
+	maxCarouselSize    = 50 * 1000
+	maxFlexURLLength   = 2000
+	maxFlexContents    = 1000
+)
+
+var (
+	flexPixelPattern       = regexp.MustCompile(`^\d+(\.\d+)?px$`)
+	flexPercentagePattern  = regexp.MustCompile(`^\d+(\.\d+)?%$`)
+	flexColorPattern       = regexp.MustCompile(`^#([0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	flexAspectRatioPattern = regexp.MustCompile(`^[1-9]\d*(\.\d+)?:[1-9]\d*(\.\d+)?$`)
+
+	flexSpacingKeywords   = []string{"none", "xs", "sm", "md", "lg", "xl", "xxl"}
+	flexTextSizeKeywords  = []string{"xxs", "xs", "sm", "md", "lg", "xl", "xxl", "3xl", "4xl", "5xl"}
+	flexImageSizeKeywords = []string{"xxs", "xs", "sm", "md", "lg", "xl", "xxl", "3xl", "4xl", "5xl", "full"}
+	flexBubbleSizes       = []string{"nano", "micro", "deca", "hecto", "kilo", "mega", "giga"}
+	flexVideoBubbleSizes  = []string{"kilo", "mega", "giga"}
+	flexComponentTypes    = []string{"box", "button", "image", "video", "icon", "text", "span", "separator", "filler"}
+)
+
+// validateFlexMessage validates a Flex Message and its container
+func (v *objectValidator) validateFlexMessage(message map[string]any, path string) {
+	v.requireString(message, path, "altText", 1, maxAltTextLength)
+	contents, ok := v.requireObject(message, path, "contents")
+	if !ok {
+		return
+	}
+	v.validateFlexContainer(contents, propertyPath(path, "contents"))
+}
+
+// validateFlexContainer validates a bubble or carousel container
+func (v *objectValidator) validateFlexContainer(container map[string]any, path string) {
+	switch containerType, _ := v.requireEnum(container, path, "type", "bubble", "carousel"); containerType {
+	case "bubble":
+		v.validateFlexBubble(container, path, false)
+		if size := jsonSize(container); size > maxBubbleSize {
+			v.fail(path, fmt.Sprintf("Size of a bubble must be %d bytes or less", maxBubbleSize))
+		}
+	case "carousel":
+		bubbles, ok := v.requireArray(container, path, "contents", 1, maxCarouselBubbles)
+		if ok {
+			for i, element := range bubbles {
+				bubblePath := indexPath(propertyPath(path, "contents"), i)
+				bubble, ok := v.elementObject(element, bubblePath)
+				if !ok {
+					continue
+				}
+				if bubbleType, ok := v.requireEnum(bubble, bubblePath, "type", "bubble"); ok && bubbleType == "bubble" {
+					v.validateFlexBubble(bubble, bubblePath, true)
+				}
+			}
+		}
+		if size := jsonSize(container); size > maxCarouselSize {
+			v.fail(path, fmt.Sprintf("Size of a carousel must be %d bytes or less", maxCarouselSize))
+		}
+	}
+}
+
+// validateFlexBubble validates the blocks of a bubble
+func (v *objectValidator) validateFlexBubble(bubble map[string]any, path string, inCarousel bool) {
+	size, sizeOK := v.optionalEnum(bubble, path, "size", flexBubbleSizes...)
+	// Bubbles are mega unless the size is specified
+	if bubble["size"] == nil {
+		size, sizeOK = "mega", true
+	}
+	v.optionalEnum(bubble, path, "direction", "ltr", "rtl")
+	v.object(bubble, path, "styles")
+	v.validateActionProperty(bubble, path, "action", flexActionRules, false)
+
+	for _, block := range []string{"header", "hero", "body", "footer"} {
+		component, ok := v.object(bubble, path, block)
+		if !ok {
+			continue
+		}
+		blockPath := propertyPath(path, block)
+		allowed := []string{"box"}
+		if block == "hero" {
+			allowed = []string{"box", "image", "video"}
+		}
+		componentType, ok := v.requireEnum(component, blockPath, "type", allowed...)
+		if !ok {
+			continue
+		}
+		if componentType == "video" {
+			// Videos are only shown in the hero block of a kilo, mega or giga bubble outside a carousel
+			if inCarousel {
+				v.fail(propertyPath(blockPath, "type"), "Video can't be used in a carousel")
+			} else if sizeOK && !slices.Contains(flexVideoBubbleSizes, size) {
+				v.fail(propertyPath(path, "size"), "must be one of: kilo, mega, giga when the hero block is a video")
+			}
+		}
+		v.validateFlexComponent(component, blockPath, "")
+	}
+}
+
+// validateFlexComponent validates a component and its children.
+// parentLayout is the layout of the box containing the component, or empty for the components of bubble blocks.
+func (v *objectValidator) validateFlexComponent(component map[string]any, path, parentLayout string) {
+	componentType, ok := v.requireEnum(component, path, "type", flexComponentTypes...)
+	if !ok {
+		return
+	}
+	if parentLayout == "baseline" && !slices.Contains([]string{"icon", "text", "filler"}, componentType) {
+		v.fail(propertyPath(path, "type"), "must be one of: icon, text, filler in a baseline box")
+		return
+	}
+	if parentLayout != "baseline" && componentType == "icon" {
+		v.fail(propertyPath(path, "type"), "Icons can only be used in a baseline box")
+		return
+	}
+	if componentType == "span" {
+		v.fail(propertyPath(path, "type"), "Spans can only be used in the contents of a text")
+		return
+	}
+	if componentType == "video" && parentLayout != "" {
+		v.fail(propertyPath(path, "type"), "Videos can only be used in the hero block")
+		return
+	}
+
+	if componentType != "filler" {
+		v.optionalSpacing(component, path, "margin")
+	}
+	switch componentType {
+	case "box":
+		v.validateFlexBox(component, path)
+	case "text":
+		v.validateFlexText(component, path)
+	case "image":
+		v.validateFlexImage(component, path)
+	case "button":
+		v.validateActionProperty(component, path, "action", flexButtonActionRules, true)
+		v.optionalEnum(component, path, "style", "primary", "secondary", "link")
+		v.optionalEnum(component, path, "height", "sm", "md")
+		v.optionalEnum(component, path, "gravity", "top", "bottom", "center")
+		v.optionalColor(component, path, "color")
+		v.optionalFlex(component, path)
+	case "icon":
+		v.requireHTTPSURL(component, path, "url", maxFlexURLLength)
+		v.optionalKeywordSize(component, path, "size", flexTextSizeKeywords, false)
+		v.optionalAspectRatio(component, path)
+	case "separator":
+		v.optionalColor(component, path, "color")
+	case "filler":
+		v.optionalFlex(component, path)
+	case "video":
+		v.validateFlexVideo(component, path)
+	}
+}
+
+// validateFlexBox validates a box and the components in it
+func (v *objectValidator) validateFlexBox(box map[string]any, path string) {
+	layout, layoutOK := v.requireEnum(box, path, "layout", "horizontal", "vertical", "baseline")
+	v.optionalFlex(box, path)
+	v.optionalSpacing(box, path, "spacing")
+	for _, key := range []string{"paddingAll", "paddingTop", "paddingBottom", "paddingStart", "paddingEnd"} {
+		v.optionalKeywordSize(box, path, key, flexSpacingKeywords, true)
+	}
+	for _, key := range []string{"width", "maxWidth", "height", "maxHeight"} {
+		v.optionalKeywordSize(box, path, key, nil, true)
+	}
+	v.optionalKeywordSize(box, path, "cornerRadius", flexSpacingKeywords, false)
+	v.optionalColor(box, path, "backgroundColor")
+	v.optionalColor(box, path, "borderColor")
+	v.optionalEnum(box, path, "position", "relative", "absolute")
+	v.optionalEnum(box, path, "justifyContent", "center", "flex-start", "flex-end", "space-between", "space-around", "space-evenly")
+	v.optionalEnum(box, path, "alignItems", "center", "flex-start", "flex-end")
+	v.validateActionProperty(box, path, "action", flexActionRules, false)
+
+	contents, ok := v.requireArray(box, path, "contents", 0, maxFlexContents)
+	if !ok || !layoutOK {
+		return
+	}
+	for i, element := range contents {
+		childPath := indexPath(propertyPath(path, "contents"), i)
+		child, ok := v.elementObject(element, childPath)
+		if !ok {
+			continue
+		}
+		v.validateFlexComponent(child, childPath, layout)
+	}
+}
+
+// validateFlexText validates a text and the spans in it
+func (v *objectValidator) validateFlexText(text map[string]any, path string) {
+	spans, hasSpans := v.array(text, path, "contents", 0, maxFlexContents)
+	if hasSpans && len(spans) > 0 {
+		v.optionalString(text, path, "text", 0, maxTextLength)
+	} else {
+		v.requireString(text, path, "text", 1, maxTextLength)
+	}
+	v.validateFlexTextStyle(text, path)
+	v.optionalEnum(text, path, "align", "start", "end", "center")
+	v.optionalEnum(text, path, "gravity", "top", "bottom", "center")
+	v.optionalFlex(text, path)
+	if maxLines, ok := v.integer(text, path, "maxLines"); ok && maxLines < 0 {
+		v.fail(propertyPath(path, "maxLines"), "must be 0 or greater")
+	}
+	v.validateActionProperty(text, path, "action", flexActionRules, false)
+
+	for i, element := range spans {
+		spanPath := indexPath(propertyPath(path, "contents"), i)
+		span, ok := v.elementObject(element, spanPath)
+		if !ok {
+			continue
+		}
+		if _, ok := v.requireEnum(span, spanPath, "type", "span"); !ok {
+			continue
+		}
+		v.requireString(span, spanPath, "text", 1, maxTextLength)
+		v.validateFlexTextStyle(span, spanPath)
+	}
+}
+
+// validateFlexTextStyle validates the properties shared by texts and spans
+func (v *objectValidator) validateFlexTextStyle(text map[string]any, path string) {
+	v.optionalKeywordSize(text, path, "size", flexTextSizeKeywords, false)
+	v.optionalEnum(text, path, "weight", "regular", "bold")
+	v.optionalEnum(text, path, "style", "normal", "italic")
+	v.optionalEnum(text, path, "decoration", "none", "underline", "line-through")
+	v.optionalColor(text, path, "color")
+}
+
+// validateFlexImage validates an image component
+func (v *objectValidator) validateFlexImage(image map[string]any, path string) {
+	v.requireHTTPSURL(image, path, "url", maxFlexURLLength)
+	v.optionalKeywordSize(image, path, "size", flexImageSizeKeywords, true)
+	v.optionalAspectRatio(image, path)
+	v.optionalEnum(image, path, "aspectMode", "cover", "fit")
+	v.optionalEnum(image, path, "align", "start", "end", "center")
+	v.optionalEnum(image, path, "gravity", "top", "bottom", "center")
+	v.optionalColor(image, path, "backgroundColor")
+	v.optionalFlex(image, path)
+	v.validateActionProperty(image, path, "action", flexActionRules, false)
+}
+
+// validateFlexVideo validates a video component and its alternative content
+func (v *objectValidator) validateFlexVideo(video map[string]any, path string) {
+	v.requireHTTPSURL(video, path, "url", maxFlexURLLength)
+	v.requireHTTPSURL(video, path, "previewUrl", maxFlexURLLength)
+	v.optionalAspectRatio(video, path)
+	v.validateActionProperty(video, path, "action", flexActionRules, false)
+
+	altContent, ok := v.requireObject(video, path, "altContent")
+	if !ok {
+		return
+	}
+	altContentPath := propertyPath(path, "altContent")
+	switch altContentType, _ := v.requireEnum(altContent, altContentPath, "type", "box", "image"); altContentType {
+	case "box":
+		v.validateFlexBox(altContent, altContentPath)
+	case "image":
+		v.validateFlexImage(altContent, altContentPath)
+	}
+}
+
+// optionalFlex checks that the flex ratio is 0 or greater
+func (v *objectValidator) optionalFlex(obj map[string]any, path string) {
+	if flex, ok := v.integer(obj, path, "flex"); ok && flex < 0 {
+		v.fail(propertyPath(path, "flex"), "must be 0 or greater")
+	}
+}
+
+// optionalSpacing checks that the spacing or margin is a keyword or in pixels
+func (v *objectValidator) optionalSpacing(obj map[string]any, path, key string) {
+	v.optionalKeywordSize(obj, path, key, flexSpacingKeywords, false)
+}
+
+// optionalKeywordSize checks that the size is one of the keywords, in pixels or, if allowed, a percentage
+func (v *objectValidator) optionalKeywordSize(obj map[string]any, path, key string, keywords []string, allowPercentage bool) {
+	s, ok := v.str(obj, path, key)
+	if !ok {
+		return
+	}
+	if slices.Contains(keywords, s) || flexPixelPattern.MatchString(s) || (allowPercentage && flexPercentagePattern.MatchString(s)) {
+		return
+	}
+	v.fail(propertyPath(path, key), fmt.Sprintf("Invalid size: %s", s))
+}
+
+// optionalColor checks that the color is a hex color code
+func (v *objectValidator) optionalColor(obj map[string]any, path, key string) {
+	if s, ok := v.str(obj, path, key); ok && !flexColorPattern.MatchString(s) {
+		v.fail(propertyPath(path, key), "must be a hex color code such as #RRGGBB or #RRGGBBAA")
+	}
+}
+
+// optionalAspectRatio checks that the aspect ratio is in the {width}:{height} format
+func (v *objectValidator) optionalAspectRatio(obj map[string]any, path string) {
+	if s, ok := v.str(obj, path, "aspectRatio"); ok && !flexAspectRatioPattern.MatchString(s) {
+		v.fail(propertyPath(path, "aspectRatio"), "must be in the {width}:{height} format")
+	}
+}
+
+// jsonSize returns the size of the object encoded in JSON
+func jsonSize(obj any) int {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
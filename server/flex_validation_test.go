@@ -0,0 +1,153 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFlexMessage(t *testing.T) {
+	// flexMessage wraps the container in a Flex Message
+	flexMessage := func(container string) string {
+		return fmt.Sprintf(`{"type":"flex","altText":"Flex Message","contents":%s}`, container)
+	}
+	bubble := func(body string) string {
+		return fmt.Sprintf(`{"type":"bubble","body":%s}`, body)
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		want    []validationDetail
+	}{
+		{
+			name: "bubble with every component",
+			message: flexMessage(`{
+				"type":"bubble","size":"mega",
+				"header":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"Header","size":"xl","weight":"bold","color":"#1DB446"}]},
+				"hero":{"type":"image","url":"https://example.com/hero.png","size":"full","aspectRatio":"20:13","aspectMode":"cover"},
+				"body":{"type":"box","layout":"vertical","spacing":"sm","paddingAll":"20px","contents":[
+					{"type":"box","layout":"baseline","contents":[
+						{"type":"icon","url":"https://example.com/star.png","size":"sm"},
+						{"type":"text","text":"4.0","flex":0,"margin":"md"},
+						{"type":"filler"}
+					]},
+					{"type":"separator","margin":"lg"},
+					{"type":"text","contents":[{"type":"span","text":"Hello, ","weight":"bold"},{"type":"span","text":"world","decoration":"underline"}]}
+				]},
+				"footer":{"type":"box","layout":"vertical","contents":[{"type":"button","style":"primary","height":"sm","action":{"type":"uri","label":"Website","uri":"https://example.com"}}]}
+			}`),
+		},
+		{
+			name:    "without altText and contents",
+			message: `{"type":"flex"}`,
+			want: []validationDetail{
+				{"messages[0].altText", "must be specified"},
+				{"messages[0].contents", "must be specified"},
+			},
+		},
+		{
+			name:    "invalid container type",
+			message: flexMessage(`{"type":"box"}`),
+			want:    []validationDetail{{"messages[0].contents.type", "must be one of: bubble, carousel"}},
+		},
+		{
+			name:    "box without layout",
+			message: flexMessage(bubble(`{"type":"box","contents":[]}`)),
+			want:    []validationDetail{{"messages[0].contents.body.layout", "must be specified"}},
+		},
+		{
+			name:    "text without text",
+			message: flexMessage(bubble(`{"type":"box","layout":"vertical","contents":[{"type":"text","size":"huge"}]}`)),
+			want: []validationDetail{
+				{"messages[0].contents.body.contents[0].text", "must be specified"},
+				{"messages[0].contents.body.contents[0].size", "Invalid size: huge"},
+			},
+		},
+		{
+			name:    "invalid enums and keywords",
+			message: flexMessage(bubble(`{"type":"box","layout":"diagonal","spacing":"huge","contents":[]}`)),
+			want: []validationDetail{
+				{"messages[0].contents.body.layout", "must be one of: horizontal, vertical, baseline"},
+				{"messages[0].contents.body.spacing", "Invalid size: huge"},
+			},
+		},
+		{
+			name:    "button without action",
+			message: flexMessage(bubble(`{"type":"box","layout":"vertical","contents":[{"type":"button","style":"danger"}]}`)),
+			want: []validationDetail{
+				{"messages[0].contents.body.contents[0].action", "must be specified"},
+				{"messages[0].contents.body.contents[0].style", "must be one of: primary, secondary, link"},
+			},
+		},
+		{
+			name:    "image with http URL and invalid aspect ratio",
+			message: flexMessage(`{"type":"bubble","hero":{"type":"image","url":"http://example.com/hero.png","aspectRatio":"wide"}}`),
+			want: []validationDetail{
+				{"messages[0].contents.hero.url", "must be an HTTPS URL"},
+				{"messages[0].contents.hero.aspectRatio", "must be in the {width}:{height} format"},
+			},
+		},
+		{
+			name:    "icon outside baseline box",
+			message: flexMessage(bubble(`{"type":"box","layout":"vertical","contents":[{"type":"icon","url":"https://example.com/star.png"}]}`)),
+			want:    []validationDetail{{"messages[0].contents.body.contents[0].type", "Icons can only be used in a baseline box"}},
+		},
+		{
+			name:    "image in baseline box",
+			message: flexMessage(bubble(`{"type":"box","layout":"baseline","contents":[{"type":"image","url":"https://example.com/a.png"}]}`)),
+			want:    []validationDetail{{"messages[0].contents.body.contents[0].type", "must be one of: icon, text, filler in a baseline box"}},
+		},
+		{
+			name:    "invalid color",
+			message: flexMessage(bubble(`{"type":"box","layout":"vertical","backgroundColor":"red","contents":[]}`)),
+			want:    []validationDetail{{"messages[0].contents.body.backgroundColor", "must be a hex color code such as #RRGGBB or #RRGGBBAA"}},
+		},
+		{
+			name:    "video in hero",
+			message: flexMessage(`{"type":"bubble","size":"mega","hero":{"type":"video","url":"https://example.com/video.mp4","previewUrl":"https://example.com/preview.png","altContent":{"type":"image","url":"https://example.com/preview.png"},"aspectRatio":"16:9"}}`),
+		},
+		{
+			name:    "video in bubble without size",
+			message: flexMessage(`{"type":"bubble","hero":{"type":"video","url":"https://example.com/video.mp4","previewUrl":"https://example.com/preview.png","altContent":{"type":"image","url":"https://example.com/preview.png"},"aspectRatio":"16:9"}}`),
+		},
+		{
+			name:    "video in bubble with invalid size",
+			message: flexMessage(`{"type":"bubble","size":"huge","hero":{"type":"video","url":"https://example.com/video.mp4","previewUrl":"https://example.com/preview.png","altContent":{"type":"image","url":"https://example.com/preview.png"}}}`),
+			want:    []validationDetail{{"messages[0].contents.size", "must be one of: nano, micro, deca, hecto, kilo, mega, giga"}},
+		},
+		{
+			name:    "video in small bubble",
+			message: flexMessage(`{"type":"bubble","size":"micro","hero":{"type":"video","url":"https://example.com/video.mp4","previewUrl":"https://example.com/preview.png","altContent":{"type":"image","url":"https://example.com/preview.png"}}}`),
+			want:    []validationDetail{{"messages[0].contents.size", "must be one of: kilo, mega, giga when the hero block is a video"}},
+		},
+		{
+			name:    "video in body",
+			message: flexMessage(bubble(`{"type":"box","layout":"vertical","contents":[{"type":"video","url":"https://example.com/video.mp4"}]}`)),
+			want:    []validationDetail{{"messages[0].contents.body.contents[0].type", "Videos can only be used in the hero block"}},
+		},
+		{
+			name:    "carousel with too many bubbles",
+			message: flexMessage(`{"type":"carousel","contents":[` + strings.TrimSuffix(strings.Repeat(`{"type":"bubble"},`, 13), ",") + `]}`),
+			want:    []validationDetail{{"messages[0].contents.contents", "Size must be between 1 and 12"}},
+		},
+		{
+			name:    "carousel with non-bubble",
+			message: flexMessage(`{"type":"carousel","contents":[{"type":"bubble"},{"type":"carousel"}]}`),
+			want:    []validationDetail{{"messages[0].contents.contents[1].type", "must be one of: bubble"}},
+		},
+		{
+			name:    "bubble too large",
+			message: flexMessage(bubble(`{"type":"box","layout":"vertical","contents":[{"type":"text","text":"` + strings.Repeat("a", 4000) + `"}` + strings.Repeat(`,{"type":"text","text":"`+strings.Repeat("a", 4000)+`"}`, 7) + `]}`)),
+			want:    []validationDetail{{"messages[0].contents", "Size of a bubble must be 30000 bytes or less"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validationDetails(t, tt.message))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+)
+
+const (
+	imagemapBaseWidth           = 1040
+	maxImagemapURLLength        = 2000
+	maxImagemapActions          = 50
+	maxImagemapLinkURILength    = 1000
+	maxImagemapMessageLength    = 400
+	maxImagemapClipboardLength  = 1000
+	maxImagemapActionLabel      = 100
+	maxImagemapExternalLinkText = 30
+)
+
+// validateImagemapMessage validates an imagemap message and checks that its areas are within the base size
+func (v *objectValidator) validateImagemapMessage(message map[string]any, path string) {
+	v.requireHTTPSURL(message, path, "baseUrl", maxImagemapURLLength)
+	v.requireString(message, path, "altText", 1, maxAltTextLength)
+	baseSize, hasBaseSize := v.validateImagemapBaseSize(message, path)
+
+	// Areas can only be checked against the base size when it is valid
+	var bounds *imageSize
+	if hasBaseSize {
+		bounds = &baseSize
+	}
+
+	if video, ok := v.object(message, path, "video"); ok {
+		v.validateImagemapVideo(video, propertyPath(path, "video"), bounds)
+	}
+
+	actions, ok := v.requireArray(message, path, "actions", 1, maxImagemapActions)
+	if !ok {
+		return
+	}
+	for i, element := range actions {
+		actionPath := indexPath(propertyPath(path, "actions"), i)
+		action, ok := v.elementObject(element, actionPath)
+		if !ok {
+			continue
+		}
+		v.validateImagemapAction(action, actionPath, bounds)
+	}
+}
+
+// validateImagemapBaseSize validates the base size. The width must always be 1040px.
+func (v *objectValidator) validateImagemapBaseSize(message map[string]any, path string) (imageSize, bool) {
+	baseSize, ok := v.requireObject(message, path, "baseSize")
+	if !ok {
+		return imageSize{}, false
+	}
+
+	baseSizePath := propertyPath(path, "baseSize")
+	width, hasWidth := v.requireInteger(baseSize, baseSizePath, "width", 1)
+	if hasWidth && width != imagemapBaseWidth {
+		v.fail(propertyPath(baseSizePath, "width"), fmt.Sprintf("must be %d", imagemapBaseWidth))
+		hasWidth = false
+	}
+	height, hasHeight := v.requireInteger(baseSize, baseSizePath, "height", 1)
+	return imageSize{width: width, height: height}, hasWidth && hasHeight
+}
+
+// validateImagemapAction validates an action of an imagemap and its area
+func (v *objectValidator) validateImagemapAction(action map[string]any, path string, bounds *imageSize) {
+	switch actionType, _ := v.requireEnum(action, path, "type", "uri", "message", "clipboard"); actionType {
+	case "uri":
+		v.requireURI(action, path, "linkUri", maxImagemapLinkURILength)
+	case "message":
+		v.requireString(action, path, "text", 1, maxImagemapMessageLength)
+	case "clipboard":
+		v.requireString(action, path, "clipboardText", 1, maxImagemapClipboardLength)
+	}
+	v.optionalString(action, path, "label", 1, maxImagemapActionLabel)
+	v.validateArea(action, path, "area", bounds)
+}
+
+// validateImagemapVideo validates the video played in an imagemap and the link shown after it ends
+func (v *objectValidator) validateImagemapVideo(video map[string]any, path string, bounds *imageSize) {
+	v.requireHTTPSURL(video, path, "originalContentUrl", maxImagemapURLLength)
+	v.requireHTTPSURL(video, path, "previewImageUrl", maxImagemapURLLength)
+	v.validateArea(video, path, "area", bounds)
+
+	externalLink, ok := v.object(video, path, "externalLink")
+	if !ok {
+		return
+	}
+	externalLinkPath := propertyPath(path, "externalLink")
+	v.requireURI(externalLink, externalLinkPath, "linkUri", maxImagemapLinkURILength)
+	v.requireString(externalLink, externalLinkPath, "label", 1, maxImagemapExternalLinkText)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateImagemapMessage(t *testing.T) {
+	// imagemapMessage builds an imagemap message with a 1040x1040 base size
+	imagemapMessage := func(actions string, extra string) string {
+		return fmt.Sprintf(`{"type":"imagemap","baseUrl":"https://example.com/bot/images/rm001","altText":"Imagemap","baseSize":{"width":1040,"height":1040},"actions":%s%s}`, actions, extra)
+	}
+	video := func(area string) string {
+		return fmt.Sprintf(`,"video":{"originalContentUrl":"https://example.com/video.mp4","previewImageUrl":"https://example.com/preview.jpg","area":%s,"externalLink":{"linkUri":"https://example.com/see_more.html","label":"See More"}}`, area)
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		want    []validationDetail
+	}{
+		{
+			name:    "imagemap with video and clipboard action",
+			message: imagemapMessage(`[{"type":"clipboard","clipboardText":"3B48740B","area":{"x":0,"y":0,"width":1040,"height":1040}}]`, video(`{"x":0,"y":0,"width":1040,"height":585}`)),
+		},
+		{
+			name:    "without required properties",
+			message: `{"type":"imagemap"}`,
+			want: []validationDetail{
+				{"messages[0].baseUrl", "must be specified"},
+				{"messages[0].altText", "must be specified"},
+				{"messages[0].baseSize", "must be specified"},
+				{"messages[0].actions", "must be specified"},
+			},
+		},
+		{
+			name:    "invalid base size",
+			message: `{"type":"imagemap","baseUrl":"https://example.com/bot/images/rm001","altText":"Imagemap","baseSize":{"width":700,"height":0},"actions":[{"type":"message","text":"Hello","area":{"x":0,"y":0,"width":2000,"height":2000}}]}`,
+			want: []validationDetail{
+				{"messages[0].baseSize.width", "must be 1040"},
+				{"messages[0].baseSize.height", "must be 1 or greater"},
+			},
+		},
+		{
+			name:    "action area out of bounds",
+			message: imagemapMessage(`[{"type":"message","text":"Hello","area":{"x":520,"y":600,"width":521,"height":441}}]`, ""),
+			want: []validationDetail{
+				{"messages[0].actions[0].area", "must be within the width of the image (1040)"},
+				{"messages[0].actions[0].area", "must be within the height of the image (1040)"},
+			},
+		},
+		{
+			name:    "action with invalid area values",
+			message: imagemapMessage(`[{"type":"uri","linkUri":"https://example.com/","area":{"x":-1,"y":0,"width":0}}]`, ""),
+			want: []validationDetail{
+				{"messages[0].actions[0].area.x", "must be 0 or greater"},
+				{"messages[0].actions[0].area.width", "must be 1 or greater"},
+				{"messages[0].actions[0].area.height", "must be specified"},
+			},
+		},
+		{
+			name:    "invalid action type",
+			message: imagemapMessage(`[{"type":"postback","data":"a","area":{"x":0,"y":0,"width":1,"height":1}}]`, ""),
+			want:    []validationDetail{{"messages[0].actions[0].type", "must be one of: uri, message, clipboard"}},
+		},
+		{
+			name:    "video area out of bounds",
+			message: imagemapMessage(`[{"type":"message","text":"Hello","area":{"x":0,"y":0,"width":1040,"height":1040}}]`, video(`{"x":0,"y":500,"width":1040,"height":585}`)),
+			want:    []validationDetail{{"messages[0].video.area", "must be within the height of the image (1040)"}},
+		},
+		{
+			name:    "video external link without label",
+			message: imagemapMessage(`[{"type":"message","text":"Hello","area":{"x":0,"y":0,"width":1040,"height":1040}}]`, `,"video":{"originalContentUrl":"https://example.com/video.mp4","previewImageUrl":"https://example.com/preview.jpg","area":{"x":0,"y":0,"width":1040,"height":585},"externalLink":{"linkUri":"https://example.com/"}}`),
+			want:    []validationDetail{{"messages[0].video.externalLink.label", "must be specified"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validationDetails(t, tt.message))
+		})
+	}
+}
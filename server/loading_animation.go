@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+)
+
+const (
+	defaultLoadingSeconds = 20
+	maxLoadingSeconds     = 60
+	loadingSecondsStep    = 5
+)
+
+// ShowLoadingAnimation starts the loading animation in the chat with a user.
+// Starting it again while it is shown restarts it with the new loading seconds.
+func (s *server) ShowLoadingAnimation(ctx context.Context, request messagingapi.ShowLoadingAnimationRequestObject) (messagingapi.ShowLoadingAnimationResponseObject, error) {
+	if request.Body == nil {
+		return nil, NewValidationError("Request body is required")
+	}
+
+	v := newObjectValidator()
+	// The loading animation can be shown only in one-on-one chats
+	if !strings.HasPrefix(request.Body.ChatId, "U") {
+		v.fail("chatId", "must be a user ID")
+	}
+	loadingSeconds := int32(defaultLoadingSeconds)
+	if request.Body.LoadingSeconds != nil {
+		loadingSeconds = *request.Body.LoadingSeconds
+		if loadingSeconds < loadingSecondsStep || loadingSeconds > maxLoadingSeconds || loadingSeconds%loadingSecondsStep != 0 {
+			v.fail("loadingSeconds", fmt.Sprintf("must be a multiple of %d between %d and %d", loadingSecondsStep, loadingSecondsStep, maxLoadingSeconds))
+		}
+	}
+	if err := v.result(); err != nil {
+		return nil, err
+	}
+
+	botID := auth.GetBotID(ctx)
+	isFollower, err := s.db.IsBotFollower(ctx, db.IsBotFollowerParams{BotID: botID, UserID: request.Body.ChatId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check chat: %w", err)
+	}
+	if !isFollower {
+		return nil, NewValidationError("The property, 'chatId', in the request body is invalid")
+	}
+
+	now := s.now()
+	if _, err := s.db.UpsertLoadingAnimation(ctx, db.UpsertLoadingAnimationParams{
+		BotID:          botID,
+		ChatID:         request.Body.ChatId,
+		LoadingSeconds: loadingSeconds,
+		StartedAt:      pgtype.Timestamptz{Time: now, Valid: true},
+		ExpiresAt:      pgtype.Timestamptz{Time: now.Add(time.Duration(loadingSeconds) * time.Second), Valid: true},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store loading animation: %w", err)
+	}
+
+	return messagingapi.ShowLoadingAnimation202JSONResponse{}, nil
+}
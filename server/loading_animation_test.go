@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestShowLoadingAnimation(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	_, err = dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_alice",
+		DisplayName: "Alice",
+	})
+	require.NoError(t, err)
+	_, err = srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+		BotId:  createdBot.UserId,
+		UserId: "U_alice",
+	})
+	require.NoError(t, err)
+
+	showLoadingAnimation := func(t *testing.T, chatID string, loadingSeconds *int32) (messagingapi.ShowLoadingAnimationResponseObject, error) {
+		t.Helper()
+		return srv.ShowLoadingAnimation(botCtx, messagingapi.ShowLoadingAnimationRequestObject{
+			Body: &messagingapi.ShowLoadingAnimationRequest{
+				ChatId:         chatID,
+				LoadingSeconds: loadingSeconds,
+			},
+		})
+	}
+	getLoadingAnimation := func(t *testing.T, srv server.Server) adminapi.GetLoadingAnimation200JSONResponse {
+		t.Helper()
+		resp, err := srv.GetLoadingAnimation(ctx, adminapi.GetLoadingAnimationRequestObject{
+			BotId:  createdBot.UserId,
+			ChatId: "U_alice",
+		})
+		require.NoError(t, err)
+		animation, ok := resp.(adminapi.GetLoadingAnimation200JSONResponse)
+		require.True(t, ok, "Expected GetLoadingAnimation200JSONResponse, got %T", resp)
+		return animation
+	}
+
+	t.Run("shows the loading animation until it expires", func(t *testing.T) {
+		resp, err := showLoadingAnimation(t, "U_alice", lo.ToPtr(int32(30)))
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.ShowLoadingAnimation202JSONResponse{}, resp)
+
+		animation := getLoadingAnimation(t, srv)
+		assert.True(t, animation.Active)
+		assert.Equal(t, lo.ToPtr(int32(30)), animation.LoadingSeconds)
+		require.NotNil(t, animation.ExpiresAt)
+		assert.Equal(t, 30*time.Second, animation.ExpiresAt.Sub(*animation.StartedAt))
+
+		later := server.New(dbClient, server.WithClock(func() time.Time {
+			return time.Now().Add(31 * time.Second)
+		}))
+		assert.False(t, getLoadingAnimation(t, later).Active)
+	})
+
+	t.Run("stops the loading animation when the bot sends a message", func(t *testing.T) {
+		_, err := showLoadingAnimation(t, "U_alice", nil)
+		require.NoError(t, err)
+		assert.Equal(t, lo.ToPtr(int32(20)), getLoadingAnimation(t, srv).LoadingSeconds)
+
+		pushCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       "U_alice",
+			"messages": []map[string]any{{"type": "text", "text": "Here is the answer"}},
+		})
+		_, err = srv.PushMessage(pushCtx, messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To:       "U_alice",
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.False(t, getLoadingAnimation(t, srv).Active)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			chatID         string
+			loadingSeconds *int32
+			property       string
+		}{
+			{name: "group chat", chatID: "C1234567890abcdef", property: "chatId"},
+			{name: "not a multiple of 5", chatID: "U_alice", loadingSeconds: lo.ToPtr(int32(12)), property: "loadingSeconds"},
+			{name: "over 60 seconds", chatID: "U_alice", loadingSeconds: lo.ToPtr(int32(65)), property: "loadingSeconds"},
+			{name: "0 seconds", chatID: "U_alice", loadingSeconds: lo.ToPtr(int32(0)), property: "loadingSeconds"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := showLoadingAnimation(t, tt.chatID, tt.loadingSeconds)
+				var validationErr *server.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				require.Len(t, validationErr.Details, 1)
+				assert.Equal(t, tt.property, lo.FromPtr(validationErr.Details[0].Property))
+			})
+		}
+
+		_, err := showLoadingAnimation(t, "U_stranger", nil)
+		var validationErr *server.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
@@ -1,9 +1,18 @@
 package server_test
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
 	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+	"github.com/zero-color/line-messaging-api-emulator/internal/requestid"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+	"github.com/zero-color/line-messaging-api-emulator/server"
 )
 
 func TestMain(m *testing.M) {
@@ -12,3 +21,29 @@ func TestMain(m *testing.M) {
 
 	m.Run()
 }
+
+// withRequestBody sets the raw request body and a new request ID in the context in the same way as the server does,
+// so that handlers can read the full message objects
+func withRequestBody(t *testing.T, ctx context.Context, body any) context.Context {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	return requestid.SetRequestID(rawbody.SetBody(ctx, raw), lineid.NewRequestID())
+}
+
+// createBot creates a bot with the mark-as-read mode if specified, and returns it with a context authenticated as the bot
+func createBot(t *testing.T, srv server.Server, dbClient db.Querier, name string, markAsReadMode ...adminapi.CreateBotRequestMarkAsReadMode) (adminapi.CreateBot201JSONResponse, context.Context) {
+	t.Helper()
+	ctx := context.Background()
+	body := &adminapi.CreateBotRequest{DisplayName: name}
+	if len(markAsReadMode) > 0 {
+		body.MarkAsReadMode = &markAsReadMode[0]
+	}
+	resp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{Body: body})
+	require.NoError(t, err)
+	createdBot, ok := resp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok, "Expected CreateBot201JSONResponse, got %T", resp)
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	return createdBot, auth.SetBotID(ctx, bot.ID)
+}
@@ -0,0 +1,105 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestMarkMessagesAsRead(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	readAt := time.Date(2024, 6, 14, 12, 0, 0, 0, time.UTC)
+	srv := server.New(dbClient, server.WithClock(func() time.Time { return readAt }))
+	ctx := context.Background()
+
+	manualBot, manualBotCtx := createBot(t, srv, dbClient, "Manual Bot", adminapi.CreateBotRequestMarkAsReadModeManual)
+	autoBot, _ := createBot(t, srv, dbClient, "Auto Bot", adminapi.CreateBotRequestMarkAsReadModeAuto)
+
+	for _, userID := range []string{"U_alice", "U_bob"} {
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+	}
+
+	sendUserMessage := func(t *testing.T, botID, userID, text string) {
+		t.Helper()
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  botID,
+			UserId: userID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr(text),
+			},
+		})
+		require.NoError(t, err)
+		require.IsType(t, adminapi.SendUserMessage202JSONResponse{}, resp)
+	}
+	getConversation := func(t *testing.T, botID, chatID string) []adminapi.ConversationMessage {
+		t.Helper()
+		resp, err := srv.GetConversation(ctx, adminapi.GetConversationRequestObject{
+			BotId:  botID,
+			ChatId: chatID,
+		})
+		require.NoError(t, err)
+		conversation, ok := resp.(adminapi.GetConversation200JSONResponse)
+		require.True(t, ok, "Expected GetConversation200JSONResponse, got %T", resp)
+		return conversation.Messages
+	}
+	markAsRead := func(t *testing.T, userID string) (messagingapi.MarkMessagesAsReadResponseObject, error) {
+		t.Helper()
+		return srv.MarkMessagesAsRead(manualBotCtx, messagingapi.MarkMessagesAsReadRequestObject{
+			Body: &messagingapi.MarkMessagesAsReadRequest{
+				Chat: messagingapi.ChatReference{UserId: userID},
+			},
+		})
+	}
+
+	t.Run("keeps messages unread in manual mode until the bot marks them as read", func(t *testing.T) {
+		sendUserMessage(t, manualBot.UserId, "U_alice", "Hello")
+		sendUserMessage(t, manualBot.UserId, "U_bob", "Hi")
+
+		messages := getConversation(t, manualBot.UserId, "U_alice")
+		require.Len(t, messages, 1)
+		assert.Nil(t, messages[0].ReadAt)
+
+		resp, err := markAsRead(t, "U_alice")
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.MarkMessagesAsRead200Response{}, resp)
+
+		messages = getConversation(t, manualBot.UserId, "U_alice")
+		require.Len(t, messages, 1)
+		require.NotNil(t, messages[0].ReadAt)
+		assert.True(t, readAt.Equal(*messages[0].ReadAt))
+
+		// Messages in other chats stay unread
+		messages = getConversation(t, manualBot.UserId, "U_bob")
+		require.Len(t, messages, 1)
+		assert.Nil(t, messages[0].ReadAt)
+	})
+
+	t.Run("marks messages as read when they are received in auto mode", func(t *testing.T) {
+		sendUserMessage(t, autoBot.UserId, "U_alice", "Hello")
+
+		messages := getConversation(t, autoBot.UserId, "U_alice")
+		require.Len(t, messages, 1)
+		require.NotNil(t, messages[0].ReadAt)
+		assert.True(t, readAt.Equal(*messages[0].ReadAt))
+	})
+
+	t.Run("rejects a request without the user ID", func(t *testing.T) {
+		_, err := markAsRead(t, "")
+		var validationErr *server.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "chat.userId", lo.FromPtr(validationErr.Details[0].Property))
+	})
+}
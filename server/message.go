@@ -3,46 +3,61 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/samber/lo"
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
 	"github.com/zero-color/line-messaging-api-emulator/db"
 	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
-	"github.com/zero-color/line-messaging-api-emulator/internal/xuuid"
-	"strings"
+	"github.com/zero-color/line-messaging-api-emulator/internal/requestid"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
 )
 
-// handleRetryKey processes the retry key from request parameters and checks for duplicate messages
-// Returns the parsed retry key UUID and true if the message already exists (should return early)
-func (s *server) handleRetryKey(ctx context.Context, retryKey *uuid.UUID) (pgtype.UUID, bool, error) {
-	var retryKeyUUID pgtype.UUID
-
-	if retryKey == nil {
-		return retryKeyUUID, false, nil
+// excludeBlockedUsers removes the users who have blocked the bot from the recipients
+func (s *server) excludeBlockedUsers(ctx context.Context, botID int32, recipients []string) ([]string, error) {
+	blocked, err := s.db.GetBlockedUserIDs(ctx, db.GetBlockedUserIDsParams{
+		BotID:   botID,
+		UserIds: recipients,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
 	}
+	return lo.Without(recipients, blocked...), nil
+}
 
-	retryKeyStr := retryKey.String()
-	parsedUUID, err := uuid.Parse(retryKeyStr)
+// pushRecipientType infers whether the recipient of a push message is a user, group or room from the prefix of its ID,
+// and checks that the user follows the bot or the bot is a member of the group or room
+func (s *server) pushRecipientType(ctx context.Context, botID int32, to string) (string, error) {
+	invalidTo := NewValidationError("The property, 'to', in the request body is invalid")
+
+	var recipientType string
+	var isRecipient bool
+	var err error
+	switch {
+	case strings.HasPrefix(to, "U"):
+		recipientType = webhook.SourceTypeUser
+		isRecipient, err = s.db.IsBotFollower(ctx, db.IsBotFollowerParams{BotID: botID, UserID: to})
+	case strings.HasPrefix(to, "C"):
+		recipientType = webhook.SourceTypeGroup
+		isRecipient, err = s.db.IsBotChatMember(ctx, db.IsBotChatMemberParams{BotID: botID, ChatType: recipientType, ChatID: to})
+	case strings.HasPrefix(to, "R"):
+		recipientType = webhook.SourceTypeRoom
+		isRecipient, err = s.db.IsBotChatMember(ctx, db.IsBotChatMemberParams{BotID: botID, ChatType: recipientType, ChatID: to})
+	default:
+		return "", invalidTo
+	}
 	if err != nil {
-		// Invalid UUID format, just return empty UUID
-		return retryKeyUUID, false, nil
+		return "", fmt.Errorf("failed to check recipient: %w", err)
 	}
-
-	retryKeyUUID.Bytes = parsedUUID
-	retryKeyUUID.Valid = true
-
-	// Check if message with this retry key already exists
-	_, err = s.db.GetMessagesByRetryKey(ctx, retryKeyUUID)
-	if err == nil {
-		// Message already exists, indicate early return
-		return retryKeyUUID, true, nil
+	if !isRecipient {
+		return "", invalidTo
 	}
-
-	// Message doesn't exist, proceed with normal flow
-	return retryKeyUUID, false, nil
+	return recipientType, nil
 }
 
 // Broadcast sends a message to all users
@@ -52,40 +67,69 @@ func (s *server) Broadcast(ctx context.Context, request messagingapi.BroadcastRe
 	}
 
 	// Validate messages
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
 	botID := auth.GetBotID(ctx)
 
 	// Handle retry key for idempotency
-	retryKeyUUID, isDuplicate, err := s.handleRetryKey(ctx, request.Params.XLineRetryKey)
+	retryKeyUUID, accepted, err := s.handleRetryKey(ctx, botID, request.Params.XLineRetryKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to handle retry key: %w", err)
+		return nil, err
 	}
-	if isDuplicate {
-		return messagingapi.Broadcast200JSONResponse{}, nil
+	if accepted != nil {
+		return newRetryKeyConflictResponse(accepted), nil
 	}
 
 	// Serialize messages to JSON
-	messagesJSON, err := json.Marshal(request.Body.Messages)
+	messages, err := messageObjects(ctx, request.Body.Messages)
+	if err != nil {
+		return nil, err
+	}
+	messagesJSON, err := json.Marshal(messages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize messages: %w", err)
 	}
+	if err := s.validateQuoteTokens(ctx, botID, messages); err != nil {
+		return nil, err
+	}
+
+	// Broadcast messages reach the users who follow the bot at the time of sending
+	followers, err := s.db.GetAllBotFollowerUserIDs(ctx, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+	quota, err := s.messageQuota(ctx, botID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !quota.allows(len(followers)) {
+		return messagingapi.Broadcast429JSONResponse{Message: quotaExceededMessage}, nil
+	}
 
 	// Store the message in database
 	recipientType := "all"
 
-	_, err = s.db.CreateMessage(ctx, db.CreateMessageParams{
+	msg, _, accepted, err := s.createMessage(ctx, db.CreateMessageParams{
 		BotID:         botID,
 		MessageType:   "broadcast",
 		RecipientType: &recipientType,
 		RecipientID:   nil,
 		Content:       messagesJSON,
 		RetryKey:      retryKeyUUID,
-	})
+		RequestID:     lo.EmptyableToPtr(requestid.GetRequestID(ctx)),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}, len(messages))
 	if err != nil {
-		return nil, fmt.Errorf("failed to store message: %w", err)
+		return nil, err
+	}
+	if accepted != nil {
+		return newRetryKeyConflictResponse(accepted), nil
+	}
+
+	if err := s.deliver(ctx, msg, webhook.SourceTypeUser, followers, messages); err != nil {
+		return nil, err
 	}
 
 	// Return empty response on success
@@ -99,7 +143,7 @@ func (s *server) Multicast(ctx context.Context, request messagingapi.MulticastRe
 	}
 
 	// Validate messages
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
@@ -119,34 +163,70 @@ func (s *server) Multicast(ctx context.Context, request messagingapi.MulticastRe
 	botID := auth.GetBotID(ctx)
 
 	// Handle retry key for idempotency
-	retryKeyUUID, isDuplicate, err := s.handleRetryKey(ctx, request.Params.XLineRetryKey)
+	retryKeyUUID, accepted, err := s.handleRetryKey(ctx, botID, request.Params.XLineRetryKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to handle retry key: %w", err)
+		return nil, err
 	}
-	if isDuplicate {
-		return messagingapi.Multicast200JSONResponse{}, nil
+	if accepted != nil {
+		return newRetryKeyConflictResponse(accepted), nil
 	}
 
 	// Serialize messages to JSON
-	messagesJSON, err := json.Marshal(request.Body.Messages)
+	messages, err := messageObjects(ctx, request.Body.Messages)
+	if err != nil {
+		return nil, err
+	}
+	messagesJSON, err := json.Marshal(messages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize messages: %w", err)
 	}
+	if err := s.validateQuoteTokens(ctx, botID, messages); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateAggregationUnits(ctx, botID, request.Body.CustomAggregationUnits); err != nil {
+		return nil, err
+	}
 
-	// Store recipient IDs as comma-separated string
-	recipientIDs := strings.Join(request.Body.To, ",")
+	// Messages to unknown users and users who blocked the bot are silently dropped.
+	// The message is stored even if it has no recipients, so that retried requests are answered with 409.
+	recipients, err := s.followingUsers(ctx, botID, request.Body.To)
+	if err != nil {
+		return nil, err
+	}
+	quota, err := s.messageQuota(ctx, botID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !quota.allows(len(recipients)) {
+		return messagingapi.Multicast429JSONResponse{Message: quotaExceededMessage}, nil
+	}
+
+	// Store the message in database. The recipients are stored as deliveries.
 	recipientType := "multiple"
 
-	_, err = s.db.CreateMessage(ctx, db.CreateMessageParams{
+	msg, _, accepted, err := s.createMessage(ctx, db.CreateMessageParams{
 		BotID:         botID,
 		MessageType:   "multicast",
 		RecipientType: &recipientType,
-		RecipientID:   &recipientIDs,
+		RecipientID:   nil,
 		Content:       messagesJSON,
 		RetryKey:      retryKeyUUID,
-	})
+		RequestID:     lo.EmptyableToPtr(requestid.GetRequestID(ctx)),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}, len(messages))
 	if err != nil {
-		return nil, fmt.Errorf("failed to store message: %w", err)
+		return nil, err
+	}
+	if accepted != nil {
+		return newRetryKeyConflictResponse(accepted), nil
+	}
+
+	if err := s.createAggregationUnits(ctx, msg, request.Body.CustomAggregationUnits); err != nil {
+		return nil, err
+	}
+	if err := s.deliver(ctx, msg, webhook.SourceTypeUser, recipients, messages); err != nil {
+		return nil, err
 	}
 
 	// Return empty response on success
@@ -160,64 +240,115 @@ func (s *server) Narrowcast(ctx context.Context, request messagingapi.Narrowcast
 	}
 
 	// Validate messages
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
 	botID := auth.GetBotID(ctx)
 
 	// Handle retry key for idempotency
-	retryKeyUUID, isDuplicate, err := s.handleRetryKey(ctx, request.Params.XLineRetryKey)
+	retryKeyUUID, accepted, err := s.handleRetryKey(ctx, botID, request.Params.XLineRetryKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to handle retry key: %w", err)
+		return nil, err
 	}
-	if isDuplicate {
-		return messagingapi.Narrowcast202JSONResponse{}, nil
+	if accepted != nil {
+		return newRetryKeyConflictResponse(accepted), nil
 	}
 
 	// Serialize messages to JSON
-	messagesJSON, err := json.Marshal(request.Body.Messages)
+	messages, err := messageObjects(ctx, request.Body.Messages)
+	if err != nil {
+		return nil, err
+	}
+	messagesJSON, err := json.Marshal(messages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize messages: %w", err)
 	}
+	if err := s.validateQuoteTokens(ctx, botID, messages); err != nil {
+		return nil, err
+	}
 
-	// Serialize filter if provided
-	var filterJSON []byte
-	if request.Body.Filter != nil {
-		filterJSON, err = json.Marshal(request.Body.Filter)
-		if err != nil {
-			return nil, fmt.Errorf("failed to serialize filter: %w", err)
-		}
+	// Validate the filter and limit before the message is accepted
+	filterJSON, err := narrowcastFilterObject(ctx, request.Body.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseDemographicFilter(filterJSON); err != nil {
+		return nil, err
+	}
+	var limitMax *int32
+	var upToRemainingQuota bool
+	if request.Body.Limit != nil {
+		limitMax = request.Body.Limit.Max
+		upToRemainingQuota = lo.FromPtr(request.Body.Limit.UpToRemainingQuota)
+	}
+	if limitMax != nil && *limitMax < 1 {
+		v := newObjectValidator()
+		v.fail("limit.max", "must be 1 or greater")
+		return nil, v.result()
+	}
+
+	// The recipients are counted against the quota when they are selected, so only a used up quota is rejected here
+	quota, err := s.messageQuota(ctx, botID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !quota.allows(1) {
+		return messagingapi.Narrowcast429JSONResponse{Message: quotaExceededMessage}, nil
 	}
 
 	// Store the message in database
 	recipientType := "filtered"
-	var recipientID *string
-	if filterJSON != nil {
-		filterStr := string(filterJSON)
-		recipientID = &filterStr
+	// The request ID is used to get the progress of the message, so one is issued if the API call has none
+	requestID := requestid.GetRequestID(ctx)
+	if requestID == "" {
+		requestID = lineid.NewRequestID()
 	}
-
-	_, err = s.db.CreateMessage(ctx, db.CreateMessageParams{
+	msg, _, accepted, err := s.createMessage(ctx, db.CreateMessageParams{
 		BotID:         botID,
 		MessageType:   "narrowcast",
 		RecipientType: &recipientType,
-		RecipientID:   recipientID,
 		Content:       messagesJSON,
 		RetryKey:      retryKeyUUID,
-	})
+		RequestID:     lo.EmptyableToPtr(requestID),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}, len(messages))
 	if err != nil {
-		return nil, fmt.Errorf("failed to store message: %w", err)
+		return nil, err
+	}
+	if accepted != nil {
+		return newRetryKeyConflictResponse(accepted), nil
+	}
+
+	// The recipients are selected and the message is delivered by the NarrowcastSender
+	if _, err := s.db.CreateNarrowcastJob(ctx, db.CreateNarrowcastJobParams{
+		BotID:              botID,
+		MessageID:          msg.ID,
+		RequestID:          requestID,
+		Filter:             filterJSON,
+		LimitMax:           limitMax,
+		UpToRemainingQuota: upToRemainingQuota,
+		AcceptedAt:         pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store narrowcast job: %w", err)
 	}
 
-	// Return empty response on success
 	return messagingapi.Narrowcast202JSONResponse{}, nil
 }
 
 // GetNarrowcastProgress gets the progress of a narrowcast message
 func (s *server) GetNarrowcastProgress(ctx context.Context, request messagingapi.GetNarrowcastProgressRequestObject) (messagingapi.GetNarrowcastProgressResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	job, err := s.db.GetNarrowcastJobByRequestID(ctx, db.GetNarrowcastJobByRequestIDParams{
+		BotID:     auth.GetBotID(ctx),
+		RequestID: request.Params.RequestId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return narrowcastProgressNotFoundResponse{}, nil
+		}
+		return nil, fmt.Errorf("failed to get narrowcast progress: %w", err)
+	}
+	return narrowcastProgress(job), nil
 }
 
 // PushMessage sends a push message to a single user
@@ -227,51 +358,86 @@ func (s *server) PushMessage(ctx context.Context, request messagingapi.PushMessa
 	}
 
 	// Validate messages
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
 	botID := auth.GetBotID(ctx)
 
 	// Handle retry key for idempotency
-	retryKeyUUID, isDuplicate, err := s.handleRetryKey(ctx, request.Params.XLineRetryKey)
+	retryKeyUUID, accepted, err := s.handleRetryKey(ctx, botID, request.Params.XLineRetryKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to handle retry key: %w", err)
+		return nil, err
 	}
-	if isDuplicate {
-		return messagingapi.PushMessage200JSONResponse{
-			SentMessages: []messagingapi.SentMessage{},
-		}, nil
+	if accepted != nil {
+		return s.pushRetryKeyConflictResponse(ctx, accepted)
 	}
 
 	// Serialize messages to JSON
-	messagesJSON, err := json.Marshal(request.Body.Messages)
+	messages, err := messageObjects(ctx, request.Body.Messages)
+	if err != nil {
+		return nil, err
+	}
+	messagesJSON, err := json.Marshal(messages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize messages: %w", err)
 	}
+	if err := s.validateQuoteTokens(ctx, botID, messages); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateAggregationUnits(ctx, botID, request.Body.CustomAggregationUnits); err != nil {
+		return nil, err
+	}
+
+	// Messages to users who blocked the bot are silently dropped.
+	// The message is stored even if it has no recipients, so that retried requests are answered with 409.
+	recipients, err := s.excludeBlockedUsers(ctx, botID, []string{request.Body.To})
+	if err != nil {
+		return nil, err
+	}
+	recipientType := webhook.SourceTypeUser
+	if len(recipients) > 0 {
+		recipientType, err = s.pushRecipientType(ctx, botID, request.Body.To)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// LINE counts each member of a group or room, but the members of chats aren't emulated,
+	// so a message to a group or room counts as one message towards the quota like a message to a user
+	quota, err := s.messageQuota(ctx, botID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !quota.allows(len(recipients)) {
+		return messagingapi.PushMessage429JSONResponse{Message: quotaExceededMessage}, nil
+	}
 
 	// Store the message in database
-	recipientType := "user"
 	recipientID := request.Body.To
 
-	msg, err := s.db.CreateMessage(ctx, db.CreateMessageParams{
+	msg, sentMessages, accepted, err := s.createMessage(ctx, db.CreateMessageParams{
 		BotID:         botID,
 		MessageType:   "push",
 		RecipientType: &recipientType,
 		RecipientID:   &recipientID,
 		Content:       messagesJSON,
 		RetryKey:      retryKeyUUID,
-	})
+		RequestID:     lo.EmptyableToPtr(requestid.GetRequestID(ctx)),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}, len(messages))
 	if err != nil {
-		return nil, fmt.Errorf("failed to store message: %w", err)
+		return nil, err
+	}
+	if accepted != nil {
+		return s.pushRetryKeyConflictResponse(ctx, accepted)
 	}
 
-	var sentMessages []messagingapi.SentMessage
-	for i := range request.Body.Messages {
-		sentMessages = append(sentMessages, messagingapi.SentMessage{
-			Id:         fmt.Sprintf("%d_%d", msg.ID, i+1),
-			QuoteToken: lo.ToPtr(fmt.Sprintf("%d_%d", msg.ID, i+1)),
-		})
+	if err := s.createAggregationUnits(ctx, msg, request.Body.CustomAggregationUnits); err != nil {
+		return nil, err
+	}
+	if err := s.deliver(ctx, msg, recipientType, recipients, messages); err != nil {
+		return nil, err
 	}
 
 	return messagingapi.PushMessage200JSONResponse{
@@ -279,69 +445,145 @@ func (s *server) PushMessage(ctx context.Context, request messagingapi.PushMessa
 	}, nil
 }
 
-// PushMessagesByPhone sends push messages by phone number
+// PushMessagesByPhone sends push messages by phone number.
+// The messages are delivered to the user whose phone number hashes to the to property, whether or not the user follows the bot.
+// Only delivered messages are stored: messages to unregistered phone numbers and users who blocked the bot fail with 422
+// and aren't stored, because the PNP statistics only report the number of successful deliveries.
 func (s *server) PushMessagesByPhone(ctx context.Context, request messagingapi.PushMessagesByPhoneRequestObject) (messagingapi.PushMessagesByPhoneResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// ReplyMessage sends a reply message
-func (s *server) ReplyMessage(ctx context.Context, request messagingapi.ReplyMessageRequestObject) (messagingapi.ReplyMessageResponseObject, error) {
 	if request.Body == nil {
 		return nil, NewValidationError("Request body is required")
 	}
 
 	// Validate messages
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
+	if !phoneNumberHashPattern.MatchString(request.Body.To) {
+		v := newObjectValidator()
+		v.fail("to", "must be a SHA-256 hash of a phone number in E.164 format")
+		return nil, v.result()
+	}
+	deliveryTag := lo.FromPtr(request.Params.XLineDeliveryTag)
+	if request.Params.XLineDeliveryTag != nil && (len(deliveryTag) < 16 || len(deliveryTag) > 100) {
+		return nil, NewValidationError("The value of the X-Line-Delivery-Tag header must be between 16 and 100 characters")
+	}
 
 	botID := auth.GetBotID(ctx)
 
-	// We store the reply token as a UUID in the RetryKey column for uniqueness checking
-	parsedUUID := uuid.NewMD5(xuuid.NameSpaceReplyToken, []byte(request.Body.ReplyToken))
-	replyTokenUUID := pgtype.UUID{
-		Bytes: parsedUUID,
-		Valid: true,
+	// Serialize messages to JSON
+	messages, err := messageObjects(ctx, request.Body.Messages)
+	if err != nil {
+		return nil, err
 	}
-
-	// Check if this reply token has been used before
-	if _, err := s.db.GetMessagesByRetryKey(ctx, replyTokenUUID); err == nil {
-		// Reply token has already been used, return 400 error
-		validationErr := NewValidationError("Invalid reply token")
-		validationErr.AddDetail("Reply token has already been used or expired", "replyToken")
-		return nil, validationErr
+	messagesJSON, err := json.Marshal(messages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize messages: %w", err)
+	}
+	if err := s.validateQuoteTokens(ctx, botID, messages); err != nil {
+		return nil, err
 	}
 
-	// Serialize messages to JSON
-	messagesJSON, err := json.Marshal(request.Body.Messages)
+	// Messages can't be delivered to unregistered phone numbers and users who blocked the bot
+	undeliverable := messagingapi.PushMessagesByPhone422JSONResponse{Message: "Failed to send messages"}
+	user, err := s.db.GetUserByPhoneNumberHash(ctx, strings.ToLower(request.Body.To))
 	if err != nil {
-		return nil, fmt.Errorf("failed to serialize messages: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return undeliverable, nil
+		}
+		return nil, fmt.Errorf("failed to get user by phone number: %w", err)
+	}
+	recipients, err := s.excludeBlockedUsers(ctx, botID, []string{user.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return undeliverable, nil
 	}
 
 	// Store the message in database
-	messageType := "reply"
-	recipientType := "reply"
-
-	msg, err := s.db.CreateMessage(ctx, db.CreateMessageParams{
+	recipientType := webhook.SourceTypeUser
+	msg, _, _, err := s.createMessage(ctx, db.CreateMessageParams{
 		BotID:         botID,
-		MessageType:   messageType,
+		MessageType:   "pnp",
 		RecipientType: &recipientType,
-		RecipientID:   &request.Body.ReplyToken,
+		RecipientID:   &user.UserID,
 		Content:       messagesJSON,
-		RetryKey:      replyTokenUUID, // Store reply token UUID for duplicate checking
+		RequestID:     lo.EmptyableToPtr(requestid.GetRequestID(ctx)),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}, len(messages))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deliver(ctx, msg, recipientType, recipients, messages); err != nil {
+		return nil, err
+	}
+
+	// The bot is notified of the delivery with the delivery tag
+	if deliveryTag != "" {
+		if err := s.enqueueDeliveryEvent(ctx, botID, user.UserID, deliveryTag); err != nil {
+			return nil, err
+		}
+	}
+
+	return messagingapi.PushMessagesByPhone200Response{}, nil
+}
+
+// ReplyMessage sends a reply message
+func (s *server) ReplyMessage(ctx context.Context, request messagingapi.ReplyMessageRequestObject) (messagingapi.ReplyMessageResponseObject, error) {
+	if request.Body == nil {
+		return nil, NewValidationError("Request body is required")
+	}
+
+	// Validate messages
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
+		return nil, err
+	}
+
+	botID := auth.GetBotID(ctx)
+
+	// Serialize messages to JSON
+	messages, err := messageObjects(ctx, request.Body.Messages)
+	if err != nil {
+		return nil, err
+	}
+	messagesJSON, err := json.Marshal(messages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize messages: %w", err)
+	}
+	if err := s.validateQuoteTokens(ctx, botID, messages); err != nil {
+		return nil, err
+	}
+
+	// Reply tokens can only be used once by the bot they were issued to, within the TTL
+	now := s.now()
+	replyToken, err := s.db.UseReplyToken(ctx, db.UseReplyTokenParams{
+		UsedAt:      pgtype.Timestamptz{Time: now, Valid: true},
+		Token:       request.Body.ReplyToken,
+		BotID:       botID,
+		IssuedAfter: pgtype.Timestamptz{Time: now.Add(-s.replyTokenTTL), Valid: true},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to store message: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, NewValidationError("Invalid reply token")
+		}
+		return nil, fmt.Errorf("failed to use reply token: %w", err)
 	}
 
-	// Generate sent messages with IDs for each message
-	var sentMessages []messagingapi.SentMessage
-	for i := range request.Body.Messages {
-		sentMessages = append(sentMessages, messagingapi.SentMessage{
-			Id:         fmt.Sprintf("%d_%d", msg.ID, i+1),
-			QuoteToken: lo.ToPtr(fmt.Sprintf("%d_%d", msg.ID, i+1)),
-		})
+	// Store the message in database, addressed to the chat the reply token was issued for
+	msg, sentMessages, _, err := s.createMessage(ctx, db.CreateMessageParams{
+		BotID:         botID,
+		MessageType:   "reply",
+		RecipientType: &replyToken.SourceType,
+		RecipientID:   &replyToken.SourceID,
+		Content:       messagesJSON,
+		RequestID:     lo.EmptyableToPtr(requestid.GetRequestID(ctx)),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now(), Valid: true},
+	}, len(messages))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deliver(ctx, msg, replyToken.SourceType, []string{replyToken.SourceID}, messages); err != nil {
+		return nil, err
 	}
 
 	// Return response with sent messages
@@ -356,7 +598,7 @@ func (s *server) ValidateBroadcast(ctx context.Context, request messagingapi.Val
 		return nil, NewValidationError("Request body is required")
 	}
 
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
@@ -369,7 +611,7 @@ func (s *server) ValidateMulticast(ctx context.Context, request messagingapi.Val
 		return nil, NewValidationError("Request body is required")
 	}
 
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
@@ -382,7 +624,7 @@ func (s *server) ValidateNarrowcast(ctx context.Context, request messagingapi.Va
 		return nil, NewValidationError("Request body is required")
 	}
 
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
@@ -395,7 +637,7 @@ func (s *server) ValidatePush(ctx context.Context, request messagingapi.Validate
 		return nil, NewValidationError("Request body is required")
 	}
 
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
@@ -408,96 +650,32 @@ func (s *server) ValidateReply(ctx context.Context, request messagingapi.Validat
 		return nil, NewValidationError("Request body is required")
 	}
 
-	if err := validateMessages(request.Body.Messages); err != nil {
+	if err := validateMessages(ctx, request.Body.Messages); err != nil {
 		return nil, err
 	}
 
 	return messagingapi.ValidateReply200Response{}, nil
 }
 
-// GetMessageContent gets the content of a message
-func (s *server) GetMessageContent(ctx context.Context, request messagingapi.GetMessageContentRequestObject) (messagingapi.GetMessageContentResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// GetMessageContentPreview gets the preview of message content
-func (s *server) GetMessageContentPreview(ctx context.Context, request messagingapi.GetMessageContentPreviewRequestObject) (messagingapi.GetMessageContentPreviewResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// GetMessageContentTranscodingByMessageId gets transcoding status by message ID
-func (s *server) GetMessageContentTranscodingByMessageId(ctx context.Context, request messagingapi.GetMessageContentTranscodingByMessageIdRequestObject) (messagingapi.GetMessageContentTranscodingByMessageIdResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// MarkMessagesAsRead marks messages as read
+// MarkMessagesAsRead marks the messages the user sent to the bot as read
+// Messages sent to bots in auto mode are marked as read when they are received, so the request has no effect on them.
 func (s *server) MarkMessagesAsRead(ctx context.Context, request messagingapi.MarkMessagesAsReadRequestObject) (messagingapi.MarkMessagesAsReadResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// ShowLoadingAnimation shows loading animation
-func (s *server) ShowLoadingAnimation(ctx context.Context, request messagingapi.ShowLoadingAnimationRequestObject) (messagingapi.ShowLoadingAnimationResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// validateMessages validates an array of message objects
-func validateMessages(messages []messagingapi.Message) error {
-	if len(messages) == 0 {
-		validationErr := NewValidationError("The request body has 1 error(s)")
-		validationErr.AddDetail("Size must be between 1 and 5", "messages")
-		return validationErr
-	}
-
-	if len(messages) > 5 {
-		validationErr := NewValidationError("The request body has 1 error(s)")
-		validationErr.AddDetail("Size must be between 1 and 5", "messages")
-		return validationErr
-	}
-
-	// Collect all validation errors
-	var errorCount int
-	validationErr := NewValidationError("")
-
-	for i, msg := range messages {
-		if err := validateMessage(msg, i, validationErr); err != nil {
-			errorCount++
-		}
+	if request.Body == nil {
+		return nil, NewValidationError("Request body is required")
 	}
-
-	if errorCount > 0 {
-		validationErr.Message = fmt.Sprintf("The request body has %d error(s)", errorCount)
-		return validationErr
+	if request.Body.Chat.UserId == "" {
+		v := newObjectValidator()
+		v.fail("chat.userId", "must be specified")
+		return nil, v.result()
 	}
 
-	return nil
-}
-
-// validateMessage validates a single message object
-func validateMessage(msg messagingapi.Message, index int, validationErr *ValidationError) error {
-	var hasError bool
-
-	if msg.Type == "" {
-		validationErr.AddDetail("Message type is required", fmt.Sprintf("messages[%d].type", index))
-		hasError = true
-	} else {
-		// Validate based on message type
-		switch msg.Type {
-		case "text", "image", "video", "audio", "file", "location", "sticker", "template", "imagemap", "flex":
-			// These are valid message types
-			// TODO: Add specific validation for each message type
-		default:
-			validationErr.AddDetail(fmt.Sprintf("Invalid message type: %s", msg.Type), fmt.Sprintf("messages[%d].type", index))
-			hasError = true
-		}
+	if err := s.db.MarkConversationMessagesRead(ctx, db.MarkConversationMessagesReadParams{
+		ReadAt: pgtype.Timestamptz{Time: s.now(), Valid: true},
+		BotID:  auth.GetBotID(ctx),
+		ChatID: request.Body.Chat.UserId,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to mark messages as read: %w", err)
 	}
 
-	if hasError {
-		return fmt.Errorf("validation failed")
-	}
-	return nil
+	return messagingapi.MarkMessagesAsRead200Response{}, nil
 }
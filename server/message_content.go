@@ -0,0 +1,300 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+)
+
+// previewMaxSize is the maximum width and height of preview images
+const previewMaxSize = 240
+
+// maxProcessingSeconds is the longest transcoding which can be specified for a video or audio
+const maxProcessingSeconds = 3600
+
+// messageContent is the content of an image, video, audio or file message sent by a user
+type messageContent struct {
+	contentType string
+	data        []byte
+	// preview is the JPEG preview image of images and videos. It is nil for audio and file messages.
+	preview []byte
+	// processing is how long the content is transcoded before it ends up in transcodingResult
+	processing        time.Duration
+	transcodingResult messagingapi.GetMessageContentTranscodingResponseStatus
+}
+
+// buildMessageContent builds the content of a media message from the uploaded binary
+func buildMessageContent(body adminapi.SendUserMessageRequest) (*messageContent, error) {
+	if body.Content == nil || len(*body.Content) == 0 {
+		return nil, fmt.Errorf("content is required for %s messages", body.Type)
+	}
+
+	content := &messageContent{
+		contentType:       http.DetectContentType(*body.Content),
+		data:              *body.Content,
+		transcodingResult: messagingapi.GetMessageContentTranscodingResponseStatusSucceeded,
+	}
+	if body.ContentType != nil && *body.ContentType != "" {
+		content.contentType = *body.ContentType
+	}
+
+	switch body.Type {
+	case adminapi.Image:
+		format, preview, err := generatePreview(*body.Content)
+		if err != nil {
+			return nil, fmt.Errorf("content must be a JPEG, PNG or GIF image")
+		}
+		content.contentType = "image/" + format
+		content.preview = preview
+	case adminapi.Video:
+		if body.PreviewImage == nil {
+			preview, err := encodePreview(image.NewGray(image.Rect(0, 0, previewMaxSize, previewMaxSize*9/16)))
+			if err != nil {
+				return nil, err
+			}
+			content.preview = preview
+			break
+		}
+		_, preview, err := generatePreview(*body.PreviewImage)
+		if err != nil {
+			return nil, fmt.Errorf("previewImage must be a JPEG, PNG or GIF image")
+		}
+		content.preview = preview
+	}
+
+	if body.Transcoding != nil {
+		if body.Type != adminapi.Video && body.Type != adminapi.Audio {
+			return nil, fmt.Errorf("transcoding can be specified only for video and audio messages")
+		}
+		seconds := lo.FromPtr(body.Transcoding.ProcessingSeconds)
+		if seconds < 0 || seconds > maxProcessingSeconds {
+			return nil, fmt.Errorf("transcoding.processingSeconds must be between 0 and %d", maxProcessingSeconds)
+		}
+		content.processing = time.Duration(seconds) * time.Second
+		if body.Transcoding.Result != nil {
+			switch *body.Transcoding.Result {
+			case adminapi.MessageTranscodingResultSucceeded:
+			case adminapi.MessageTranscodingResultFailed:
+				content.transcodingResult = messagingapi.GetMessageContentTranscodingResponseStatusFailed
+			default:
+				return nil, fmt.Errorf("transcoding.result must be succeeded or failed")
+			}
+		}
+	}
+	return content, nil
+}
+
+// generatePreview decodes the image and returns its format and a JPEG downscaled to fit in previewMaxSize
+func generatePreview(data []byte) (string, []byte, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longer := max(width, height); longer > previewMaxSize {
+		width = max(width*previewMaxSize/longer, 1)
+		height = max(height*previewMaxSize/longer, 1)
+	}
+
+	// Nearest-neighbor sampling is good enough for previews
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			dst.Set(x, y, src.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+
+	preview, err := encodePreview(dst)
+	if err != nil {
+		return "", nil, err
+	}
+	return format, preview, nil
+}
+
+// encodePreview encodes the preview image in JPEG
+func encodePreview(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("failed to encode preview image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// storeMessageContent keeps the content of the message in the blob store so that the bot can download it
+func (s *server) storeMessageContent(ctx context.Context, botID int32, message *webhook.Message, content *messageContent) error {
+	blobKey := message.ID
+	if err := s.blobs.Put(ctx, blobKey, content.data); err != nil {
+		return fmt.Errorf("failed to store content: %w", err)
+	}
+
+	var previewBlobKey *string
+	if content.preview != nil {
+		previewBlobKey = lo.ToPtr(message.ID + "-preview")
+		if err := s.blobs.Put(ctx, *previewBlobKey, content.preview); err != nil {
+			return fmt.Errorf("failed to store preview image: %w", err)
+		}
+	}
+
+	if _, err := s.db.CreateMessageContent(ctx, db.CreateMessageContentParams{
+		BotID:          botID,
+		MessageID:      message.ID,
+		MessageType:    message.Type,
+		ContentType:    content.contentType,
+		BlobKey:        blobKey,
+		PreviewBlobKey: previewBlobKey,
+		Size:           int64(len(content.data)),
+		// The content is processing from the time it is sent
+		TranscodingCompletedAt: pgtype.Timestamptz{Time: s.now().Add(content.processing), Valid: true},
+		TranscodingResult:      string(content.transcodingResult),
+	}); err != nil {
+		return fmt.Errorf("failed to create message content: %w", err)
+	}
+	return nil
+}
+
+// messageContentNotFoundResponse is returned when the bot received no message with content with the message ID
+type messageContentNotFoundResponse struct{}
+
+func (response messageContentNotFoundResponse) visit(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	return json.NewEncoder(w).Encode(messagingapi.ErrorResponse{Message: "Not found"})
+}
+
+func (response messageContentNotFoundResponse) VisitGetMessageContentResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response messageContentNotFoundResponse) VisitGetMessageContentPreviewResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response messageContentNotFoundResponse) VisitGetMessageContentTranscodingByMessageIdResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+// messageContentProcessingResponse is returned while the content is being transcoded
+type messageContentProcessingResponse struct{}
+
+func (response messageContentProcessingResponse) VisitGetMessageContentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusAccepted)
+	return nil
+}
+
+func (response messageContentProcessingResponse) VisitGetMessageContentPreviewResponse(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusAccepted)
+	return nil
+}
+
+// transcodingStatus returns the status of the transcoding of the content at now
+// Content which isn't transcoded is ready as soon as it is sent.
+func transcodingStatus(content db.MessageContent, now time.Time) messagingapi.GetMessageContentTranscodingResponseStatus {
+	if now.Before(content.TranscodingCompletedAt.Time) {
+		return messagingapi.GetMessageContentTranscodingResponseStatusProcessing
+	}
+	return messagingapi.GetMessageContentTranscodingResponseStatus(content.TranscodingResult)
+}
+
+// getMessageContent returns the content of the message the bot received
+func (s *server) getMessageContent(ctx context.Context, messageID string) (db.MessageContent, error) {
+	content, err := s.db.GetMessageContent(ctx, db.GetMessageContentParams{
+		BotID:     auth.GetBotID(ctx),
+		MessageID: messageID,
+	})
+	if err != nil {
+		return db.MessageContent{}, fmt.Errorf("failed to get message content: %w", err)
+	}
+	return content, nil
+}
+
+// GetMessageContent gets the content of a message
+// 202 is returned while the content is being transcoded, and 404 if the transcoding failed.
+func (s *server) GetMessageContent(ctx context.Context, request messagingapi.GetMessageContentRequestObject) (messagingapi.GetMessageContentResponseObject, error) {
+	content, err := s.getMessageContent(ctx, request.MessageId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return messageContentNotFoundResponse{}, nil
+		}
+		return nil, err
+	}
+	switch transcodingStatus(content, s.now()) {
+	case messagingapi.GetMessageContentTranscodingResponseStatusProcessing:
+		return messageContentProcessingResponse{}, nil
+	case messagingapi.GetMessageContentTranscodingResponseStatusFailed:
+		return messageContentNotFoundResponse{}, nil
+	}
+
+	data, err := s.blobs.Get(ctx, content.BlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content: %w", err)
+	}
+	return messagingapi.GetMessageContent200AsteriskResponse{
+		Body:          bytes.NewReader(data),
+		ContentType:   content.ContentType,
+		ContentLength: int64(len(data)),
+	}, nil
+}
+
+// GetMessageContentPreview gets the preview of message content
+// Only images and videos have preview images. Like the content, the preview of a video isn't available until it is transcoded.
+func (s *server) GetMessageContentPreview(ctx context.Context, request messagingapi.GetMessageContentPreviewRequestObject) (messagingapi.GetMessageContentPreviewResponseObject, error) {
+	content, err := s.getMessageContent(ctx, request.MessageId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return messageContentNotFoundResponse{}, nil
+		}
+		return nil, err
+	}
+	if content.PreviewBlobKey == nil {
+		return messageContentNotFoundResponse{}, nil
+	}
+	switch transcodingStatus(content, s.now()) {
+	case messagingapi.GetMessageContentTranscodingResponseStatusProcessing:
+		return messageContentProcessingResponse{}, nil
+	case messagingapi.GetMessageContentTranscodingResponseStatusFailed:
+		return messageContentNotFoundResponse{}, nil
+	}
+
+	data, err := s.blobs.Get(ctx, *content.PreviewBlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preview image: %w", err)
+	}
+	return messagingapi.GetMessageContentPreview200AsteriskResponse{
+		Body:          bytes.NewReader(data),
+		ContentType:   "image/jpeg",
+		ContentLength: int64(len(data)),
+	}, nil
+}
+
+// GetMessageContentTranscodingByMessageId gets transcoding status by message ID
+func (s *server) GetMessageContentTranscodingByMessageId(ctx context.Context, request messagingapi.GetMessageContentTranscodingByMessageIdRequestObject) (messagingapi.GetMessageContentTranscodingByMessageIdResponseObject, error) {
+	content, err := s.getMessageContent(ctx, request.MessageId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return messageContentNotFoundResponse{}, nil
+		}
+		return nil, err
+	}
+	return messagingapi.GetMessageContentTranscodingByMessageId200JSONResponse{
+		Status: transcodingStatus(content, s.now()),
+	}, nil
+}
@@ -0,0 +1,280 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/blobstore"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestMessageContent(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createdBot, botCtx := createBot(t, srv, dbClient, "Test Bot")
+	_, otherBotCtx := createBot(t, srv, dbClient, "Other Bot")
+
+	_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_sender",
+		DisplayName: "Sender",
+	})
+	require.NoError(t, err)
+
+	var pngImage bytes.Buffer
+	require.NoError(t, png.Encode(&pngImage, image.NewRGBA(image.Rect(0, 0, 800, 600))))
+
+	send := func(t *testing.T, srv server.Server, body adminapi.SendUserMessageRequest) adminapi.SendUserMessageResponseObject {
+		t.Helper()
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: "U_sender",
+			Body:   &body,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	sendMedia := func(t *testing.T, srv server.Server, body adminapi.SendUserMessageRequest) adminapi.SendUserMessage202JSONResponse {
+		t.Helper()
+		resp := send(t, srv, body)
+		sendResp, ok := resp.(adminapi.SendUserMessage202JSONResponse)
+		require.True(t, ok, "Expected SendUserMessage202JSONResponse, got %T", resp)
+		return sendResp
+	}
+	lastEvent := func(t *testing.T) webhook.Event {
+		t.Helper()
+		events, err := dbClient.ClaimWebhookEvents(ctx, db.ClaimWebhookEventsParams{
+			LeaseUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+			Now:        pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+			BatchSize:  10,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		var event webhook.Event
+		require.NoError(t, json.Unmarshal(events[len(events)-1].Payload, &event))
+		return event
+	}
+	// visit writes the response of a content endpoint and returns it
+	visit := func(t *testing.T, visit func(w *httptest.ResponseRecorder) error) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		require.NoError(t, visit(w))
+		return w
+	}
+	getContent := func(t *testing.T, ctx context.Context, srv server.Server, messageID string) *httptest.ResponseRecorder {
+		t.Helper()
+		resp, err := srv.GetMessageContent(ctx, messagingapi.GetMessageContentRequestObject{MessageId: messageID})
+		require.NoError(t, err)
+		return visit(t, func(w *httptest.ResponseRecorder) error { return resp.VisitGetMessageContentResponse(w) })
+	}
+	getPreview := func(t *testing.T, ctx context.Context, srv server.Server, messageID string) *httptest.ResponseRecorder {
+		t.Helper()
+		resp, err := srv.GetMessageContentPreview(ctx, messagingapi.GetMessageContentPreviewRequestObject{MessageId: messageID})
+		require.NoError(t, err)
+		return visit(t, func(w *httptest.ResponseRecorder) error { return resp.VisitGetMessageContentPreviewResponse(w) })
+	}
+
+	t.Run("serves the content and a downscaled preview of an image", func(t *testing.T) {
+		sendResp := sendMedia(t, srv, adminapi.SendUserMessageRequest{
+			Type:    adminapi.Image,
+			Content: lo.ToPtr(pngImage.Bytes()),
+		})
+
+		event := lastEvent(t)
+		require.NotNil(t, event.Message)
+		assert.Equal(t, "image", event.Message.Type)
+		assert.Equal(t, &webhook.ContentProvider{Type: "line"}, event.Message.ContentProvider)
+		assert.NotEmpty(t, event.Message.QuoteToken)
+
+		content := getContent(t, botCtx, srv, sendResp.MessageId)
+		assert.Equal(t, 200, content.Code)
+		assert.Equal(t, "image/png", content.Header().Get("Content-Type"))
+		assert.Equal(t, pngImage.Bytes(), content.Body.Bytes())
+
+		preview := getPreview(t, botCtx, srv, sendResp.MessageId)
+		assert.Equal(t, 200, preview.Code)
+		assert.Equal(t, "image/jpeg", preview.Header().Get("Content-Type"))
+		config, err := jpeg.DecodeConfig(preview.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 240, config.Width)
+		assert.Equal(t, 180, config.Height)
+
+		resp, err := srv.GetMessageContentTranscodingByMessageId(botCtx, messagingapi.GetMessageContentTranscodingByMessageIdRequestObject{
+			MessageId: sendResp.MessageId,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, messagingapi.GetMessageContentTranscodingByMessageId200JSONResponse{
+			Status: messagingapi.GetMessageContentTranscodingResponseStatusSucceeded,
+		}, resp)
+	})
+
+	t.Run("serves a video with the specified Content-Type and duration", func(t *testing.T) {
+		sendResp := sendMedia(t, srv, adminapi.SendUserMessageRequest{
+			Type:        adminapi.Video,
+			Content:     lo.ToPtr([]byte("video content")),
+			ContentType: lo.ToPtr("video/mp4"),
+			Duration:    lo.ToPtr(int64(60000)),
+		})
+
+		event := lastEvent(t)
+		require.NotNil(t, event.Message)
+		assert.Equal(t, int64(60000), event.Message.Duration)
+
+		content := getContent(t, botCtx, srv, sendResp.MessageId)
+		assert.Equal(t, "video/mp4", content.Header().Get("Content-Type"))
+		assert.Equal(t, "video content", content.Body.String())
+
+		// A blank preview image is served when none is uploaded
+		preview := getPreview(t, botCtx, srv, sendResp.MessageId)
+		assert.Equal(t, 200, preview.Code)
+		_, err := jpeg.DecodeConfig(preview.Body)
+		assert.NoError(t, err)
+	})
+
+	t.Run("serves audio without a preview image or a quote token", func(t *testing.T) {
+		sendResp := sendMedia(t, srv, adminapi.SendUserMessageRequest{
+			Type:        adminapi.Audio,
+			Content:     lo.ToPtr([]byte("audio content")),
+			ContentType: lo.ToPtr("audio/x-m4a"),
+		})
+		assert.Nil(t, sendResp.QuoteToken)
+
+		content := getContent(t, botCtx, srv, sendResp.MessageId)
+		assert.Equal(t, "audio/x-m4a", content.Header().Get("Content-Type"))
+		assert.Equal(t, 404, getPreview(t, botCtx, srv, sendResp.MessageId).Code)
+	})
+
+	t.Run("serves a file with its name and size in the message event", func(t *testing.T) {
+		sendResp := sendMedia(t, srv, adminapi.SendUserMessageRequest{
+			Type:     adminapi.File,
+			Content:  lo.ToPtr([]byte("file content")),
+			FileName: lo.ToPtr("notes.txt"),
+		})
+
+		event := lastEvent(t)
+		require.NotNil(t, event.Message)
+		assert.Equal(t, "notes.txt", event.Message.FileName)
+		assert.Equal(t, int64(len("file content")), event.Message.FileSize)
+		assert.Empty(t, event.Message.QuoteToken)
+
+		content := getContent(t, botCtx, srv, sendResp.MessageId)
+		assert.Equal(t, "text/plain; charset=utf-8", content.Header().Get("Content-Type"))
+	})
+
+	t.Run("keeps the content in a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := blobstore.NewFileStore(dir)
+		require.NoError(t, err)
+		fileSrv := server.New(dbClient, server.WithBlobStore(store))
+
+		sendResp := sendMedia(t, fileSrv, adminapi.SendUserMessageRequest{
+			Type:    adminapi.Image,
+			Content: lo.ToPtr(pngImage.Bytes()),
+		})
+		data, err := store.Get(ctx, sendResp.MessageId)
+		require.NoError(t, err)
+		assert.Equal(t, pngImage.Bytes(), data)
+
+		content := getContent(t, botCtx, fileSrv, sendResp.MessageId)
+		assert.Equal(t, pngImage.Bytes(), content.Body.Bytes())
+	})
+
+	t.Run("doesn't serve the content of messages sent to another bot", func(t *testing.T) {
+		sendResp := sendMedia(t, srv, adminapi.SendUserMessageRequest{
+			Type:    adminapi.Image,
+			Content: lo.ToPtr(pngImage.Bytes()),
+		})
+
+		content := getContent(t, otherBotCtx, srv, sendResp.MessageId)
+		assert.Equal(t, 404, content.Code)
+		body, err := io.ReadAll(content.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"message":"Not found"}`, string(body))
+	})
+
+	t.Run("goes through transcoding which ends up in the specified result", func(t *testing.T) {
+		later := server.New(dbClient, server.WithClock(func() time.Time {
+			return time.Now().Add(time.Minute)
+		}))
+		transcodingStatus := func(t *testing.T, srv server.Server, messageID string) messagingapi.GetMessageContentTranscodingResponseStatus {
+			t.Helper()
+			resp, err := srv.GetMessageContentTranscodingByMessageId(botCtx, messagingapi.GetMessageContentTranscodingByMessageIdRequestObject{
+				MessageId: messageID,
+			})
+			require.NoError(t, err)
+			status, ok := resp.(messagingapi.GetMessageContentTranscodingByMessageId200JSONResponse)
+			require.True(t, ok, "Expected GetMessageContentTranscodingByMessageId200JSONResponse, got %T", resp)
+			return status.Status
+		}
+
+		for _, tt := range []struct {
+			result      adminapi.MessageTranscodingResult
+			status      messagingapi.GetMessageContentTranscodingResponseStatus
+			contentCode int
+		}{
+			{adminapi.MessageTranscodingResultSucceeded, messagingapi.GetMessageContentTranscodingResponseStatusSucceeded, 200},
+			{adminapi.MessageTranscodingResultFailed, messagingapi.GetMessageContentTranscodingResponseStatusFailed, 404},
+		} {
+			sendResp := sendMedia(t, srv, adminapi.SendUserMessageRequest{
+				Type:    adminapi.Video,
+				Content: lo.ToPtr([]byte("video content")),
+				Transcoding: &adminapi.MessageTranscoding{
+					ProcessingSeconds: lo.ToPtr(10),
+					Result:            lo.ToPtr(tt.result),
+				},
+			})
+
+			// The content isn't available while it is processing
+			assert.Equal(t, messagingapi.GetMessageContentTranscodingResponseStatusProcessing, transcodingStatus(t, srv, sendResp.MessageId))
+			assert.Equal(t, 202, getContent(t, botCtx, srv, sendResp.MessageId).Code)
+			assert.Equal(t, 202, getPreview(t, botCtx, srv, sendResp.MessageId).Code)
+
+			assert.Equal(t, tt.status, transcodingStatus(t, later, sendResp.MessageId))
+			assert.Equal(t, tt.contentCode, getContent(t, botCtx, later, sendResp.MessageId).Code)
+			assert.Equal(t, tt.contentCode, getPreview(t, botCtx, later, sendResp.MessageId).Code)
+		}
+	})
+
+	t.Run("rejects invalid media messages", func(t *testing.T) {
+		for name, body := range map[string]adminapi.SendUserMessageRequest{
+			"without content":       {Type: adminapi.Video},
+			"image which isn't one": {Type: adminapi.Image, Content: lo.ToPtr([]byte("not an image"))},
+			"file without a name":   {Type: adminapi.File, Content: lo.ToPtr([]byte("file content"))},
+			"negative duration":     {Type: adminapi.Audio, Content: lo.ToPtr([]byte("audio")), Duration: lo.ToPtr(int64(-1))},
+			"transcoding of an image": {
+				Type:        adminapi.Image,
+				Content:     lo.ToPtr(pngImage.Bytes()),
+				Transcoding: &adminapi.MessageTranscoding{ProcessingSeconds: lo.ToPtr(10)},
+			},
+			"too long transcoding": {
+				Type:        adminapi.Audio,
+				Content:     lo.ToPtr([]byte("audio")),
+				Transcoding: &adminapi.MessageTranscoding{ProcessingSeconds: lo.ToPtr(3601)},
+			},
+			"unknown transcoding result": {
+				Type:        adminapi.Audio,
+				Content:     lo.ToPtr([]byte("audio")),
+				Transcoding: &adminapi.MessageTranscoding{Result: lo.ToPtr(adminapi.MessageTranscodingResult("processing"))},
+			},
+		} {
+			assert.IsType(t, adminapi.SendUserMessage400JSONResponse{}, send(t, srv, body), name)
+		}
+	})
+}
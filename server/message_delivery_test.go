@@ -0,0 +1,104 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestMessageDeliveries(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	follow := func(t *testing.T, userID string) {
+		t.Helper()
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+		_, err = srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: userID,
+		})
+		require.NoError(t, err)
+	}
+	for _, userID := range []string{"U_alice", "U_bob", "U_carol"} {
+		follow(t, userID)
+	}
+	_, err = srv.BlockBot(ctx, adminapi.BlockBotRequestObject{
+		BotId:  createdBot.UserId,
+		UserId: "U_carol",
+	})
+	require.NoError(t, err)
+
+	// recipients returns the recipients of the message sent with the API
+	recipients := func(t *testing.T, messageType string) []string {
+		t.Helper()
+		messages, err := dbClient.GetBotMessages(ctx, db.GetBotMessagesParams{
+			BotID: bot.ID,
+			Limit: 10,
+		})
+		require.NoError(t, err)
+		msg, ok := lo.Find(messages, func(m db.Message) bool { return m.MessageType == messageType })
+		require.True(t, ok, "Expected a %s message", messageType)
+
+		deliveries, err := dbClient.ListMessageDeliveries(ctx, msg.ID)
+		require.NoError(t, err)
+		return lo.Map(deliveries, func(d db.MessageDelivery, _ int) string {
+			assert.Equal(t, "user", d.RecipientType)
+			return d.RecipientID
+		})
+	}
+	messagesBody := []map[string]any{{"type": "text", "text": "Hello"}}
+
+	t.Run("multicast is delivered to followers only", func(t *testing.T) {
+		to := []string{"U_alice", "U_unknown", "U_carol", "U_alice", "U_bob"}
+		multicastCtx := withRequestBody(t, botCtx, map[string]any{"to": to, "messages": messagesBody})
+		resp, err := srv.Multicast(multicastCtx, messagingapi.MulticastRequestObject{
+			Body: &messagingapi.MulticastRequest{
+				To:       to,
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.Multicast200JSONResponse{}, resp)
+		assert.Equal(t, []string{"U_alice", "U_bob"}, recipients(t, "multicast"))
+	})
+
+	t.Run("broadcast is delivered to the followers at the time of sending", func(t *testing.T) {
+		broadcastCtx := withRequestBody(t, botCtx, map[string]any{"messages": messagesBody})
+		resp, err := srv.Broadcast(broadcastCtx, messagingapi.BroadcastRequestObject{
+			Body: &messagingapi.BroadcastRequest{
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.Broadcast200JSONResponse{}, resp)
+
+		follow(t, "U_dave")
+		assert.Equal(t, []string{"U_alice", "U_bob"}, recipients(t, "broadcast"))
+	})
+}
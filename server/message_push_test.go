@@ -0,0 +1,111 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestPushMessage(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createdBot, botCtx := createBot(t, srv, dbClient, "Test Bot")
+	otherBot, _ := createBot(t, srv, dbClient, "Other Bot")
+
+	for _, userID := range []string{"U_follower", "U_stranger"} {
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+	}
+	_, err := srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+		BotId:  createdBot.UserId,
+		UserId: "U_follower",
+	})
+	require.NoError(t, err)
+
+	joinChat := func(t *testing.T, botID string, chatType adminapi.JoinChatRequestType) string {
+		t.Helper()
+		resp, err := srv.JoinChat(ctx, adminapi.JoinChatRequestObject{
+			BotId: botID,
+			Body:  &adminapi.JoinChatRequest{Type: chatType},
+		})
+		require.NoError(t, err)
+		joinResp, ok := resp.(adminapi.JoinChat202JSONResponse)
+		require.True(t, ok, "Expected JoinChat202JSONResponse, got %T", resp)
+		return joinResp.ChatId
+	}
+	groupID := joinChat(t, createdBot.UserId, adminapi.ChatTypeGroup)
+	roomID := joinChat(t, createdBot.UserId, adminapi.ChatTypeRoom)
+	otherGroupID := joinChat(t, otherBot.UserId, adminapi.ChatTypeGroup)
+
+	push := func(t *testing.T, to string) (messagingapi.PushMessageResponseObject, error) {
+		t.Helper()
+		pushCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       to,
+			"messages": []map[string]any{{"type": "text", "text": "Hello"}},
+		})
+		return srv.PushMessage(pushCtx, messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To:       to,
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+	}
+
+	t.Run("pushes to users, groups and rooms", func(t *testing.T) {
+		tests := []struct {
+			to            string
+			recipientType string
+		}{
+			{"U_follower", "user"},
+			{groupID, "group"},
+			{roomID, "room"},
+		}
+		for _, tt := range tests {
+			resp, err := push(t, tt.to)
+			require.NoError(t, err)
+			pushResp, ok := resp.(messagingapi.PushMessage200JSONResponse)
+			require.True(t, ok, "Expected PushMessage200JSONResponse, got %T", resp)
+			assert.Len(t, pushResp.SentMessages, 1)
+
+			messages, err := dbClient.GetBotMessages(ctx, db.GetBotMessagesParams{
+				BotID: auth.GetBotID(botCtx),
+				Limit: 10,
+			})
+			require.NoError(t, err)
+			msg, ok := lo.Find(messages, func(m db.Message) bool { return lo.FromPtr(m.RecipientID) == tt.to })
+			require.True(t, ok, "Expected a message to %s", tt.to)
+			assert.Equal(t, tt.recipientType, lo.FromPtr(msg.RecipientType))
+
+			conversation, err := dbClient.ListConversationMessages(ctx, db.ListConversationMessagesParams{
+				BotID:  auth.GetBotID(botCtx),
+				ChatID: tt.to,
+			})
+			require.NoError(t, err)
+			require.NotEmpty(t, conversation)
+			assert.Equal(t, tt.recipientType, conversation[len(conversation)-1].ChatType)
+		}
+	})
+
+	t.Run("rejects chats the bot can't send messages to", func(t *testing.T) {
+		for _, to := range []string{"U_stranger", "U_unknown", otherGroupID, "Rdoesnotexist", "invalid"} {
+			_, err := push(t, to)
+			var validationErr *server.ValidationError
+			require.True(t, errors.As(err, &validationErr), "Expected ValidationError for %s, got %v", to, err)
+			assert.Equal(t, "The property, 'to', in the request body is invalid", validationErr.Message)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestReplyMessage(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createdBot, botCtx := createBot(t, srv, dbClient, "Test Bot")
+	_, otherBotCtx := createBot(t, srv, dbClient, "Other Bot")
+
+	user, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_sender",
+		DisplayName: "Sender",
+	})
+	require.NoError(t, err)
+
+	// issueReplyToken sends a user message to the bot and returns the reply token of the webhook event
+	issueReplyToken := func(t *testing.T) string {
+		t.Helper()
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello, bot"),
+			},
+		})
+		require.NoError(t, err)
+		sendResp, ok := resp.(adminapi.SendUserMessage202JSONResponse)
+		require.True(t, ok, "Expected SendUserMessage202JSONResponse, got %T", resp)
+		return sendResp.ReplyToken
+	}
+
+	reply := func(t *testing.T, ctx context.Context, srv server.Server, replyToken string) (messagingapi.ReplyMessageResponseObject, error) {
+		ctx = withRequestBody(t, ctx, map[string]any{
+			"replyToken": replyToken,
+			"messages":   []map[string]any{{"type": "text", "text": "Thanks"}},
+		})
+		return srv.ReplyMessage(ctx, messagingapi.ReplyMessageRequestObject{
+			Body: &messagingapi.ReplyMessageRequest{
+				ReplyToken: replyToken,
+				Messages: []messagingapi.Message{
+					{Type: "text"},
+				},
+			},
+		})
+	}
+
+	assertInvalidReplyToken := func(t *testing.T, err error) {
+		t.Helper()
+		var validationErr *server.ValidationError
+		require.True(t, errors.As(err, &validationErr), "Expected ValidationError, got %v", err)
+		assert.Equal(t, "Invalid reply token", validationErr.Message)
+		assert.Empty(t, validationErr.Details)
+	}
+
+	t.Run("replies to the chat the token was issued for", func(t *testing.T) {
+		replyToken := issueReplyToken(t)
+
+		resp, err := reply(t, botCtx, srv, replyToken)
+		require.NoError(t, err)
+		replyResp, ok := resp.(messagingapi.ReplyMessage200JSONResponse)
+		require.True(t, ok, "Expected ReplyMessage200JSONResponse, got %T", resp)
+		assert.Len(t, replyResp.SentMessages, 1)
+
+		messages, err := dbClient.GetBotMessages(ctx, db.GetBotMessagesParams{
+			BotID: auth.GetBotID(botCtx),
+			Limit: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "reply", messages[0].MessageType)
+		assert.Equal(t, "user", lo.FromPtr(messages[0].RecipientType))
+		assert.Equal(t, user.UserID, lo.FromPtr(messages[0].RecipientID))
+	})
+
+	t.Run("rejects a reply token which has been used", func(t *testing.T) {
+		replyToken := issueReplyToken(t)
+
+		_, err := reply(t, botCtx, srv, replyToken)
+		require.NoError(t, err)
+
+		_, err = reply(t, botCtx, srv, replyToken)
+		assertInvalidReplyToken(t, err)
+	})
+
+	t.Run("rejects an unknown reply token", func(t *testing.T) {
+		_, err := reply(t, botCtx, srv, "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA")
+		assertInvalidReplyToken(t, err)
+	})
+
+	t.Run("rejects a reply token issued to another bot", func(t *testing.T) {
+		replyToken := issueReplyToken(t)
+
+		_, err := reply(t, otherBotCtx, srv, replyToken)
+		assertInvalidReplyToken(t, err)
+
+		// The token is still usable by the bot it was issued to
+		_, err = reply(t, botCtx, srv, replyToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("issues and checks reply tokens with the server clock", func(t *testing.T) {
+		later := server.New(dbClient, server.WithClock(func() time.Time {
+			return time.Now().Add(time.Hour)
+		}))
+		resp, err := later.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: user.UserID,
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello, bot"),
+			},
+		})
+		require.NoError(t, err)
+		replyToken := resp.(adminapi.SendUserMessage202JSONResponse).ReplyToken
+
+		_, err = reply(t, botCtx, later, replyToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects an expired reply token", func(t *testing.T) {
+		replyToken := issueReplyToken(t)
+
+		later := server.New(dbClient, server.WithClock(func() time.Time {
+			return time.Now().Add(server.DefaultReplyTokenTTL + time.Second)
+		}))
+		_, err := reply(t, botCtx, later, replyToken)
+		assertInvalidReplyToken(t, err)
+	})
+}
@@ -2,27 +2,50 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
 )
 
+// sampleMessages are valid message objects of each message type that can be sent
+var sampleMessages = map[string]string{
+	"text":     `{"type":"text","text":"Hello, world"}`,
+	"image":    `{"type":"image","originalContentUrl":"https://example.com/original.jpg","previewImageUrl":"https://example.com/preview.jpg"}`,
+	"video":    `{"type":"video","originalContentUrl":"https://example.com/original.mp4","previewImageUrl":"https://example.com/preview.jpg"}`,
+	"audio":    `{"type":"audio","originalContentUrl":"https://example.com/original.m4a","duration":60000}`,
+	"location": `{"type":"location","title":"my location","address":"1-3 Kioicho, Chiyoda-ku, Tokyo, 102-8282, Japan","latitude":35.67966,"longitude":139.73669}`,
+	"sticker":  `{"type":"sticker","packageId":"446","stickerId":"1988"}`,
+	"template": `{"type":"template","altText":"This is a buttons template","template":{"type":"buttons","thumbnailImageUrl":"https://example.com/bot/images/image.jpg","title":"Menu","text":"Please select","actions":[{"type":"postback","label":"Buy","data":"action=buy&itemid=123"},{"type":"uri","label":"View detail","uri":"https://example.com/page/123"}]}}`,
+	"imagemap": `{"type":"imagemap","baseUrl":"https://example.com/bot/images/rm001","altText":"This is an imagemap","baseSize":{"width":1040,"height":1040},"actions":[{"type":"uri","linkUri":"https://example.com/","area":{"x":0,"y":586,"width":520,"height":454}},{"type":"message","text":"Hello","area":{"x":520,"y":586,"width":520,"height":454}}]}`,
+	"flex":     `{"type":"flex","altText":"This is a Flex Message","contents":{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"Hello,"},{"type":"text","text":"World!"}]}}}`,
+}
+
+// validateMessageRequest builds a validate request from message objects and sets it as the raw request body in the same way as the server does
+func validateMessageRequest(t *testing.T, messages ...string) (context.Context, *messagingapi.ValidateMessageRequest) {
+	t.Helper()
+	body := fmt.Sprintf(`{"messages":[%s]}`, strings.Join(messages, ","))
+	var request messagingapi.ValidateMessageRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &request))
+	return rawbody.SetBody(context.Background(), []byte(body)), &request
+}
+
 func TestValidateBroadcast(t *testing.T) {
 	s := &server{}
 
 	t.Run("validates valid broadcast message", func(t *testing.T) {
+		ctx, body := validateMessageRequest(t, sampleMessages["text"])
 		request := messagingapi.ValidateBroadcastRequestObject{
-			Body: &messagingapi.ValidateMessageRequest{
-				Messages: []messagingapi.Message{
-					{Type: "text"},
-				},
-			},
+			Body: body,
 		}
 
-		response, err := s.ValidateBroadcast(context.Background(), request)
+		response, err := s.ValidateBroadcast(ctx, request)
 		require.NoError(t, err)
 		assert.IsType(t, messagingapi.ValidateBroadcast200Response{}, response)
 	})
@@ -121,16 +144,12 @@ func TestValidateMulticast(t *testing.T) {
 	s := &server{}
 
 	t.Run("validates valid multicast message", func(t *testing.T) {
+		ctx, body := validateMessageRequest(t, sampleMessages["image"], sampleMessages["video"])
 		request := messagingapi.ValidateMulticastRequestObject{
-			Body: &messagingapi.ValidateMessageRequest{
-				Messages: []messagingapi.Message{
-					{Type: "image"},
-					{Type: "video"},
-				},
-			},
+			Body: body,
 		}
 
-		response, err := s.ValidateMulticast(context.Background(), request)
+		response, err := s.ValidateMulticast(ctx, request)
 		require.NoError(t, err)
 		assert.IsType(t, messagingapi.ValidateMulticast200Response{}, response)
 	})
@@ -140,36 +159,42 @@ func TestValidateNarrowcast(t *testing.T) {
 	s := &server{}
 
 	t.Run("validates valid narrowcast message", func(t *testing.T) {
+		ctx, body := validateMessageRequest(t, sampleMessages["audio"], sampleMessages["location"])
 		request := messagingapi.ValidateNarrowcastRequestObject{
-			Body: &messagingapi.ValidateMessageRequest{
-				Messages: []messagingapi.Message{
-					{Type: "audio"},
-					{Type: "file"},
-					{Type: "location"},
-				},
-			},
+			Body: body,
 		}
 
-		response, err := s.ValidateNarrowcast(context.Background(), request)
+		response, err := s.ValidateNarrowcast(ctx, request)
 		require.NoError(t, err)
 		assert.IsType(t, messagingapi.ValidateNarrowcast200Response{}, response)
 	})
+
+	t.Run("returns error for file message", func(t *testing.T) {
+		ctx, body := validateMessageRequest(t, `{"type":"file","fileName":"sample.pdf","fileSize":1024}`)
+		request := messagingapi.ValidateNarrowcastRequestObject{
+			Body: body,
+		}
+
+		_, err := s.ValidateNarrowcast(ctx, request)
+		require.Error(t, err)
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "The request body has 1 error(s)", validationErr.Message)
+		assert.Equal(t, "messages[0].type", *validationErr.Details[0].Property)
+	})
 }
 
 func TestValidatePush(t *testing.T) {
 	s := &server{}
 
 	t.Run("validates valid push message", func(t *testing.T) {
+		ctx, body := validateMessageRequest(t, sampleMessages["sticker"], sampleMessages["template"])
 		request := messagingapi.ValidatePushRequestObject{
-			Body: &messagingapi.ValidateMessageRequest{
-				Messages: []messagingapi.Message{
-					{Type: "sticker"},
-					{Type: "template"},
-				},
-			},
+			Body: body,
 		}
 
-		response, err := s.ValidatePush(context.Background(), request)
+		response, err := s.ValidatePush(ctx, request)
 		require.NoError(t, err)
 		assert.IsType(t, messagingapi.ValidatePush200Response{}, response)
 	})
@@ -179,36 +204,29 @@ func TestValidateReply(t *testing.T) {
 	s := &server{}
 
 	t.Run("validates valid reply message", func(t *testing.T) {
+		ctx, body := validateMessageRequest(t, sampleMessages["imagemap"], sampleMessages["flex"])
 		request := messagingapi.ValidateReplyRequestObject{
-			Body: &messagingapi.ValidateMessageRequest{
-				Messages: []messagingapi.Message{
-					{Type: "imagemap"},
-					{Type: "flex"},
-				},
-			},
+			Body: body,
 		}
 
-		response, err := s.ValidateReply(context.Background(), request)
+		response, err := s.ValidateReply(ctx, request)
 		require.NoError(t, err)
 		assert.IsType(t, messagingapi.ValidateReply200Response{}, response)
 	})
 
 	t.Run("validates all supported message types", func(t *testing.T) {
 		supportedTypes := []string{
-			"text", "image", "video", "audio", "file",
+			"text", "image", "video", "audio",
 			"location", "sticker", "template", "imagemap", "flex",
 		}
 
 		for _, msgType := range supportedTypes {
+			ctx, body := validateMessageRequest(t, sampleMessages[msgType])
 			request := messagingapi.ValidateReplyRequestObject{
-				Body: &messagingapi.ValidateMessageRequest{
-					Messages: []messagingapi.Message{
-						{Type: msgType},
-					},
-				},
+				Body: body,
 			}
 
-			response, err := s.ValidateReply(context.Background(), request)
+			response, err := s.ValidateReply(ctx, request)
 			require.NoError(t, err, "failed for message type: %s", msgType)
 			assert.IsType(t, messagingapi.ValidateReply200Response{}, response)
 		}
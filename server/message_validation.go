@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+)
+
+const (
+	maxTextLength         = 5000
+	maxTextEmojis         = 20
+	maxTextSubstitutions  = 100
+	maxContentURLLength   = 2000
+	maxTrackingIDLength   = 100
+	maxLocationTextLength = 100
+)
+
+var (
+	substitutionKeyPattern         = regexp.MustCompile(`^[a-zA-Z0-9_]{1,20}$`)
+	substitutionPlaceholderPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+)
+
+// validateMessages validates an array of message objects with the rules of each message type
+func validateMessages(ctx context.Context, messages []messagingapi.Message) error {
+	if len(messages) == 0 {
+		validationErr := NewValidationError("The request body has 1 error(s)")
+		validationErr.AddDetail("Size must be between 1 and 5", "messages")
+		return validationErr
+	}
+
+	if len(messages) > 5 {
+		validationErr := NewValidationError("The request body has 1 error(s)")
+		validationErr.AddDetail("Size must be between 1 and 5", "messages")
+		return validationErr
+	}
+
+	objects, err := messageObjects(ctx, messages)
+	if err != nil {
+		return err
+	}
+
+	// Collect all validation errors
+	v := newObjectValidator()
+	for i, object := range objects {
+		path := indexPath("messages", i)
+		var message map[string]any
+		if err := json.Unmarshal(object, &message); err != nil {
+			v.fail(path, "must be an object")
+			continue
+		}
+		v.validateMessage(message, path)
+	}
+	return v.result()
+}
+
+// validateMessage validates a single message object
+func (v *objectValidator) validateMessage(message map[string]any, path string) {
+	msgType, _ := v.str(message, path, "type")
+	switch msgType {
+	case "":
+		v.fail(propertyPath(path, "type"), "Message type is required")
+	case "text":
+		v.validateTextMessage(message, path)
+	case "textV2":
+		v.validateTextV2Message(message, path)
+	case "image":
+		v.requireHTTPSURL(message, path, "originalContentUrl", maxContentURLLength)
+		v.requireHTTPSURL(message, path, "previewImageUrl", maxContentURLLength)
+	case "video":
+		v.requireHTTPSURL(message, path, "originalContentUrl", maxContentURLLength)
+		v.requireHTTPSURL(message, path, "previewImageUrl", maxContentURLLength)
+		v.optionalString(message, path, "trackingId", 1, maxTrackingIDLength)
+	case "audio":
+		v.requireHTTPSURL(message, path, "originalContentUrl", maxContentURLLength)
+		if _, ok := message["duration"]; !ok {
+			v.fail(propertyPath(path, "duration"), "must be specified")
+		} else if duration, ok := v.integer(message, path, "duration"); ok && duration < 1 {
+			v.fail(propertyPath(path, "duration"), "must be greater than 0")
+		}
+	case "location":
+		v.requireString(message, path, "title", 1, maxLocationTextLength)
+		v.requireString(message, path, "address", 1, maxLocationTextLength)
+		v.requireNumber(message, path, "latitude", -90, 90)
+		v.requireNumber(message, path, "longitude", -180, 180)
+	case "sticker":
+		v.requireID(message, path, "packageId")
+		v.requireID(message, path, "stickerId")
+	case "flex":
+		v.validateFlexMessage(message, path)
+	case "template":
+		v.validateTemplateMessage(message, path)
+	case "imagemap":
+		v.validateImagemapMessage(message, path)
+	case "file":
+		v.fail(propertyPath(path, "type"), "File messages can't be sent")
+	default:
+		v.fail(propertyPath(path, "type"), fmt.Sprintf("Invalid message type: %s", msgType))
+	}
+	v.validateQuickReply(message, path)
+}
+
+// requireID checks that the ID property is set to a non-empty string
+func (v *objectValidator) requireID(obj map[string]any, path, key string) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return
+	}
+	if id, ok := v.str(obj, path, key); ok && id == "" {
+		v.fail(propertyPath(path, key), "must not be empty")
+	}
+}
+
+// validateTextMessage validates a text message and the LINE emojis in it
+func (v *objectValidator) validateTextMessage(message map[string]any, path string) {
+	text, ok := v.requireString(message, path, "text", 1, maxTextLength)
+
+	emojis, emojisOK := v.array(message, path, "emojis", 1, maxTextEmojis)
+	if !emojisOK {
+		return
+	}
+	// The index of an emoji is the position of its "$" placeholder counted in UTF-16 code units
+	units := utf16.Encode([]rune(text))
+	for i, element := range emojis {
+		emojiPath := indexPath(propertyPath(path, "emojis"), i)
+		emoji, isObject := v.elementObject(element, emojiPath)
+		if !isObject {
+			continue
+		}
+		v.requireID(emoji, emojiPath, "productId")
+		v.requireID(emoji, emojiPath, "emojiId")
+		if _, set := emoji["index"]; !set {
+			v.fail(propertyPath(emojiPath, "index"), "must be specified")
+			continue
+		}
+		index, isInteger := v.integer(emoji, emojiPath, "index")
+		if !isInteger || !ok {
+			continue
+		}
+		if index < 0 || index >= len(units) || units[index] != '$' {
+			v.fail(propertyPath(emojiPath, "index"), "must be the position of a $ character in text")
+		}
+	}
+}
+
+// validateTextV2Message validates a text message (v2) and the substitution objects for its placeholders
+func (v *objectValidator) validateTextV2Message(message map[string]any, path string) {
+	text, textOK := v.requireString(message, path, "text", 1, maxTextLength)
+
+	substitution, _ := v.object(message, path, "substitution")
+	if len(substitution) > maxTextSubstitutions {
+		v.fail(propertyPath(path, "substitution"), fmt.Sprintf("Size must be between 0 and %d", maxTextSubstitutions))
+	}
+	keys := lo.Keys(substitution)
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := substitution[key]
+		substitutionPath := propertyPath(propertyPath(path, "substitution"), key)
+		if !substitutionKeyPattern.MatchString(key) {
+			v.fail(substitutionPath, "Key must consist of 1 to 20 alphanumeric characters or underscores")
+			continue
+		}
+		object, ok := v.elementObject(value, substitutionPath)
+		if !ok {
+			continue
+		}
+		switch substitutionType, _ := v.requireEnum(object, substitutionPath, "type", "mention", "emoji"); substitutionType {
+		case "mention":
+			mentionee, ok := v.requireObject(object, substitutionPath, "mentionee")
+			if !ok {
+				continue
+			}
+			mentioneePath := propertyPath(substitutionPath, "mentionee")
+			if mentioneeType, _ := v.requireEnum(mentionee, mentioneePath, "type", "user", "all"); mentioneeType == "user" {
+				v.requireID(mentionee, mentioneePath, "userId")
+			}
+		case "emoji":
+			v.requireID(object, substitutionPath, "productId")
+			v.requireID(object, substitutionPath, "emojiId")
+		}
+	}
+
+	if !textOK {
+		return
+	}
+	// "{{" and "}}" are escaped braces, every other {key} must have a substitution
+	unescaped := strings.NewReplacer("{{", "", "}}", "").Replace(text)
+	for _, match := range substitutionPlaceholderPattern.FindAllStringSubmatch(unescaped, -1) {
+		if _, ok := substitution[match[1]]; !ok {
+			v.fail(propertyPath(path, "text"), fmt.Sprintf("Substitution for {%s} is not specified", match[1]))
+		}
+	}
+}
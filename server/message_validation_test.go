@@ -0,0 +1,138 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validationDetail is a detail of a validation error
+type validationDetail struct {
+	property string
+	message  string
+}
+
+// validationDetails validates the message objects and returns the details of the validation error
+func validationDetails(t *testing.T, messages ...string) []validationDetail {
+	t.Helper()
+	ctx, body := validateMessageRequest(t, messages...)
+	err := validateMessages(ctx, body.Messages)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), "Expected ValidationError, got %v", err)
+	assert.Equal(t, fmt.Sprintf("The request body has %d error(s)", len(validationErr.Details)), validationErr.Message)
+	details := make([]validationDetail, 0, len(validationErr.Details))
+	for _, d := range validationErr.Details {
+		details = append(details, validationDetail{lo.FromPtr(d.Property), lo.FromPtr(d.Message)})
+	}
+	return details
+}
+
+func TestValidateMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		want     []validationDetail
+	}{
+		{
+			name:     "text without text",
+			messages: []string{`{"type":"text"}`},
+			want:     []validationDetail{{"messages[0].text", "must be specified"}},
+		},
+		{
+			name:     "text too long",
+			messages: []string{`{"type":"text","text":"` + strings.Repeat("a", 5001) + `"}`},
+			want:     []validationDetail{{"messages[0].text", "Length must be between 1 and 5000"}},
+		},
+		{
+			name:     "text with emojis",
+			messages: []string{`{"type":"text","text":"$ LINE emoji $","emojis":[{"index":0,"productId":"5ac1bfd5040ab15980c9b435","emojiId":"001"},{"index":13,"productId":"5ac1bfd5040ab15980c9b435","emojiId":"002"}]}`},
+		},
+		{
+			name:     "emoji index not pointing at $",
+			messages: []string{`{"type":"text","text":"$ LINE emoji","emojis":[{"index":2,"productId":"5ac1bfd5040ab15980c9b435","emojiId":"001"}]}`},
+			want:     []validationDetail{{"messages[0].emojis[0].index", "must be the position of a $ character in text"}},
+		},
+		{
+			name:     "emoji without IDs",
+			messages: []string{`{"type":"text","text":"$","emojis":[{"index":0}]}`},
+			want: []validationDetail{
+				{"messages[0].emojis[0].productId", "must be specified"},
+				{"messages[0].emojis[0].emojiId", "must be specified"},
+			},
+		},
+		{
+			name:     "textV2 with substitution",
+			messages: []string{`{"type":"textV2","text":"Welcome, {user1}! {{literal}} {laugh}","substitution":{"user1":{"type":"mention","mentionee":{"type":"user","userId":"U49585cd0d5..."}},"laugh":{"type":"emoji","productId":"5a8555cfe6256cc92ea23c2a","emojiId":"002"}}}`},
+		},
+		{
+			name:     "textV2 with placeholder without substitution",
+			messages: []string{`{"type":"textV2","text":"Hello, {user1} and {user2}","substitution":{"user1":{"type":"mention","mentionee":{"type":"all"}}}}`},
+			want:     []validationDetail{{"messages[0].text", "Substitution for {user2} is not specified"}},
+		},
+		{
+			name:     "textV2 with invalid substitution",
+			messages: []string{`{"type":"textV2","text":"{a}","substitution":{"a":{"type":"sticker"}}}`},
+			want:     []validationDetail{{"messages[0].substitution.a.type", "must be one of: mention, emoji"}},
+		},
+		{
+			name:     "image with http and missing preview",
+			messages: []string{`{"type":"image","originalContentUrl":"http://example.com/original.jpg"}`},
+			want: []validationDetail{
+				{"messages[0].originalContentUrl", "must be an HTTPS URL"},
+				{"messages[0].previewImageUrl", "must be specified"},
+			},
+		},
+		{
+			name:     "video with too long URL",
+			messages: []string{`{"type":"video","originalContentUrl":"https://example.com/` + strings.Repeat("a", 2000) + `","previewImageUrl":"https://example.com/preview.jpg"}`},
+			want:     []validationDetail{{"messages[0].originalContentUrl", "Length must be between 1 and 2000"}},
+		},
+		{
+			name:     "audio without duration",
+			messages: []string{`{"type":"audio","originalContentUrl":"https://example.com/original.m4a"}`},
+			want:     []validationDetail{{"messages[0].duration", "must be specified"}},
+		},
+		{
+			name:     "sticker without sticker ID",
+			messages: []string{`{"type":"sticker","packageId":"446"}`},
+			want:     []validationDetail{{"messages[0].stickerId", "must be specified"}},
+		},
+		{
+			name:     "location out of range",
+			messages: []string{`{"type":"location","title":"my location","address":"Tokyo","latitude":91,"longitude":-181}`},
+			want: []validationDetail{
+				{"messages[0].latitude", "must be between -90 and 90"},
+				{"messages[0].longitude", "must be between -180 and 180"},
+			},
+		},
+		{
+			name:     "file",
+			messages: []string{`{"type":"file","fileName":"sample.pdf","fileSize":1024}`},
+			want:     []validationDetail{{"messages[0].type", "File messages can't be sent"}},
+		},
+		{
+			name:     "errors in several messages",
+			messages: []string{sampleMessages["text"], `{"type":"text","text":""}`, `{"type":"sticker"}`},
+			want: []validationDetail{
+				{"messages[1].text", "Length must be between 1 and 5000"},
+				{"messages[2].packageId", "must be specified"},
+				{"messages[2].stickerId", "must be specified"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validationDetails(t, tt.messages...))
+		})
+	}
+}
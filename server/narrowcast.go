@@ -0,0 +1,246 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+)
+
+const (
+	narrowcastPhaseSucceeded = "succeeded"
+	narrowcastPhaseFailed    = "failed"
+
+	// Error codes of failed narrowcast messages, as returned by the LINE Platform
+	narrowcastErrorCodeInternal            = 1
+	narrowcastErrorCodeNotEnoughRecipients = 2
+
+	defaultNarrowcastPollInterval = time.Second
+	narrowcastBatchSize           = 10
+	// narrowcastLeaseTimeout is how long a job can stay in the sending phase before another worker picks it up again
+	narrowcastLeaseTimeout = time.Minute
+)
+
+// narrowcastProgressNotFoundResponse is returned when no narrowcast message was sent by the bot with the request ID
+type narrowcastProgressNotFoundResponse struct{}
+
+func (response narrowcastProgressNotFoundResponse) VisitGetNarrowcastProgressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	return json.NewEncoder(w).Encode(messagingapi.ErrorResponse{Message: "Not found"})
+}
+
+// NarrowcastSender sends accepted narrowcast messages in the background.
+// Each message waits until a worker picks it up, is sent to the followers matching its filter and limit,
+// and ends up in the succeeded or failed phase.
+type NarrowcastSender struct {
+	s            *server
+	pollInterval time.Duration
+}
+
+// NarrowcastSenderOption configures a NarrowcastSender.
+type NarrowcastSenderOption func(*NarrowcastSender)
+
+// WithNarrowcastPollInterval sets how often the idle sender checks for waiting narrowcast messages.
+func WithNarrowcastPollInterval(interval time.Duration) NarrowcastSenderOption {
+	return func(n *NarrowcastSender) {
+		n.pollInterval = interval
+	}
+}
+
+// NewNarrowcastSender creates a new narrowcast sender which sends messages with the server created by New.
+// Quotas and subscription periods are measured with the clock of the server.
+func NewNarrowcastSender(srv Server, opts ...NarrowcastSenderOption) *NarrowcastSender {
+	n := &NarrowcastSender{
+		s:            srv.(*server),
+		pollInterval: defaultNarrowcastPollInterval,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Run sends waiting narrowcast messages and blocks until ctx is canceled.
+func (n *NarrowcastSender) Run(ctx context.Context) {
+	for {
+		processed, err := n.ProcessPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to send narrowcast messages", slog.Any("error", err))
+		}
+		if processed > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(n.pollInterval):
+		}
+	}
+}
+
+// ProcessPending sends the waiting narrowcast messages and returns how many messages were processed.
+// A message which fails to be sent doesn't stop the rest of the batch; the errors are returned together.
+func (n *NarrowcastSender) ProcessPending(ctx context.Context) (int, error) {
+	now := n.s.now()
+	jobs, err := n.s.db.ClaimNarrowcastJobs(ctx, db.ClaimNarrowcastJobsParams{
+		LeaseUntil: pgtype.Timestamptz{Time: now.Add(narrowcastLeaseTimeout), Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		BatchSize:  narrowcastBatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim narrowcast jobs: %w", err)
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		// The deliveries and the completion of a job are stored together,
+		// so that a job picked up again after its lease expired isn't delivered twice
+		err := n.s.inTx(ctx, func(s *server) error {
+			tx := *n
+			tx.s = s
+			return tx.send(ctx, job)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to send narrowcast message", slog.String("requestId", job.RequestID), slog.Any("error", err))
+			errs = append(errs, err)
+		}
+	}
+	return len(jobs), errors.Join(errs...)
+}
+
+// send selects the recipients of a claimed narrowcast message and delivers it to them
+func (n *NarrowcastSender) send(ctx context.Context, job db.NarrowcastJob) error {
+	filter, err := parseDemographicFilter(job.Filter)
+	if err != nil {
+		return n.complete(ctx, job, narrowcastPhaseFailed, 0, lo.ToPtr(int32(narrowcastErrorCodeInternal)), lo.ToPtr(fmt.Sprintf("Invalid filter: %v", err)))
+	}
+
+	recipients, err := n.recipients(ctx, job, filter)
+	if err != nil {
+		return err
+	}
+	if err := n.s.db.SetNarrowcastJobTargetCount(ctx, db.SetNarrowcastJobTargetCountParams{
+		TargetCount: lo.ToPtr(int32(len(recipients))),
+		ID:          job.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to set narrowcast target count: %w", err)
+	}
+	if len(recipients) == 0 {
+		return n.complete(ctx, job, narrowcastPhaseFailed, 0, lo.ToPtr(int32(narrowcastErrorCodeNotEnoughRecipients)), lo.ToPtr("There weren't enough recipients"))
+	}
+	quota, err := n.s.messageQuota(ctx, job.BotID, n.s.now())
+	if err != nil {
+		return err
+	}
+	if !quota.allows(len(recipients)) {
+		return n.complete(ctx, job, narrowcastPhaseFailed, 0, nil, lo.ToPtr(quotaExceededMessage))
+	}
+
+	msg, err := n.s.db.GetMessage(ctx, job.MessageID)
+	if err != nil {
+		return fmt.Errorf("failed to get narrowcast message: %w", err)
+	}
+	var messages []json.RawMessage
+	if err := json.Unmarshal(msg.Content, &messages); err != nil {
+		return n.complete(ctx, job, narrowcastPhaseFailed, 0, lo.ToPtr(int32(narrowcastErrorCodeInternal)), lo.ToPtr(fmt.Sprintf("Invalid messages: %v", err)))
+	}
+	if err := n.s.deliver(ctx, msg, webhook.SourceTypeUser, recipients, messages); err != nil {
+		return err
+	}
+	return n.complete(ctx, job, narrowcastPhaseSucceeded, int32(len(recipients)), nil, nil)
+}
+
+// recipients returns the followers of the bot who match the filter, chosen at random up to the limit.
+// Recipient objects aren't evaluated because audiences aren't emulated.
+func (n *NarrowcastSender) recipients(ctx context.Context, job db.NarrowcastJob, filter *demographicFilter) ([]string, error) {
+	followers, err := n.s.db.GetBotFollowerAttributes(ctx, job.BotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	now := n.s.now()
+	recipients := make([]string, 0, len(followers))
+	for _, follower := range followers {
+		member := audienceMember{
+			userID:           follower.UserID,
+			gender:           lo.FromPtr(follower.Gender),
+			age:              int(lo.FromPtr(follower.Age)),
+			appType:          lo.FromPtr(follower.AppType),
+			area:             lo.FromPtr(follower.Area),
+			subscriptionDays: subscriptionDays(follower.FollowedAt.Time, now),
+		}
+		if filter == nil || filter.matches(member) {
+			recipients = append(recipients, member.userID)
+		}
+	}
+
+	limit := len(recipients)
+	if job.LimitMax != nil {
+		limit = min(limit, int(*job.LimitMax))
+	}
+	// With upToRemainingQuota, the message is sent to as many recipients as the remaining quota allows
+	if job.UpToRemainingQuota {
+		quota, err := n.s.messageQuota(ctx, job.BotID, n.s.now())
+		if err != nil {
+			return nil, err
+		}
+		if quota.limited {
+			limit = min(limit, int(quota.remaining()))
+		}
+	}
+	if len(recipients) > limit {
+		recipients = lo.Samples(recipients, limit)
+	}
+	return recipients, nil
+}
+
+func (n *NarrowcastSender) complete(ctx context.Context, job db.NarrowcastJob, phase string, successCount int32, errorCode *int32, failedDescription *string) error {
+	params := db.CompleteNarrowcastJobParams{
+		Phase:             phase,
+		ErrorCode:         errorCode,
+		FailedDescription: failedDescription,
+		CompletedAt:       pgtype.Timestamptz{Time: n.s.now(), Valid: true},
+		ID:                job.ID,
+	}
+	if phase == narrowcastPhaseSucceeded {
+		params.SuccessCount = &successCount
+		params.FailureCount = lo.ToPtr(int32(0))
+	}
+	if err := n.s.db.CompleteNarrowcastJob(ctx, params); err != nil {
+		return fmt.Errorf("failed to complete narrowcast job: %w", err)
+	}
+	return nil
+}
+
+// narrowcastProgress converts a narrowcast job to the progress returned by the API
+func narrowcastProgress(job db.NarrowcastJob) messagingapi.GetNarrowcastProgress200JSONResponse {
+	toInt64 := func(n *int32) *int64 {
+		if n == nil {
+			return nil
+		}
+		return lo.ToPtr(int64(*n))
+	}
+	progress := messagingapi.GetNarrowcastProgress200JSONResponse{
+		Phase:             messagingapi.NarrowcastProgressResponsePhase(job.Phase),
+		AcceptedTime:      job.AcceptedAt.Time,
+		TargetCount:       toInt64(job.TargetCount),
+		SuccessCount:      toInt64(job.SuccessCount),
+		FailureCount:      toInt64(job.FailureCount),
+		ErrorCode:         toInt64(job.ErrorCode),
+		FailedDescription: job.FailedDescription,
+	}
+	if job.CompletedAt.Valid {
+		progress.CompletedTime = &job.CompletedAt.Time
+	}
+	return progress
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+)
+
+const maxDemographicFilterOperands = 10
+
+var (
+	demographicGenders             = []string{"male", "female"}
+	demographicAppTypes            = []string{"ios", "android"}
+	demographicAges                = []string{"age_15", "age_20", "age_25", "age_30", "age_35", "age_40", "age_45", "age_50", "age_55", "age_60", "age_65", "age_70"}
+	demographicSubscriptionPeriods = []string{"day_7", "day_30", "day_90", "day_180", "day_365"}
+
+	// demographicAreas are the prefectures of Japan and the regions of Taiwan, Thailand and Indonesia
+	demographicAreas = slices.Concat(areaCodes("jp", 47), areaCodes("tw", 22), areaCodes("th", 8), areaCodes("id", 12))
+)
+
+// areaCodes returns the area codes of a country, e.g. jp_01 to jp_47
+func areaCodes(country string, count int) []string {
+	codes := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		codes = append(codes, fmt.Sprintf("%s_%02d", country, i))
+	}
+	return codes
+}
+
+// demographicFilter is a node of the demographic filter tree of a narrowcast request
+type demographicFilter struct {
+	filterType string
+	// operator is and, or or not, and operands are the filters it is applied to
+	operator string
+	operands []*demographicFilter
+	// oneOf is the values of gender, appType and area filters
+	oneOf []string
+	// gte and lt are the bounds of age and subscriptionPeriod filters in years or days. 0 means unbounded.
+	gte int
+	lt  int
+}
+
+// audienceMember is a follower of the bot with the attributes demographic filters are evaluated against.
+// Attributes which aren't set are empty.
+type audienceMember struct {
+	userID           string
+	gender           string
+	age              int
+	appType          string
+	area             string
+	subscriptionDays int
+}
+
+// matches reports whether the user meets the conditions of the filter.
+// Users never match a condition on an attribute which isn't set.
+func (f *demographicFilter) matches(member audienceMember) bool {
+	switch f.filterType {
+	case "operator":
+		switch f.operator {
+		case "and":
+			return lo.EveryBy(f.operands, func(operand *demographicFilter) bool { return operand.matches(member) })
+		case "or":
+			return lo.SomeBy(f.operands, func(operand *demographicFilter) bool { return operand.matches(member) })
+		case "not":
+			return !f.operands[0].matches(member)
+		}
+	case "gender":
+		return slices.Contains(f.oneOf, member.gender)
+	case "appType":
+		return slices.Contains(f.oneOf, member.appType)
+	case "area":
+		return slices.Contains(f.oneOf, member.area)
+	case "age":
+		return member.age > 0 && f.inRange(member.age)
+	case "subscriptionPeriod":
+		return f.inRange(member.subscriptionDays)
+	}
+	return false
+}
+
+func (f *demographicFilter) inRange(value int) bool {
+	return (f.gte == 0 || value >= f.gte) && (f.lt == 0 || value < f.lt)
+}
+
+// subscriptionDays returns how many whole days the user has been following the bot
+func subscriptionDays(followedAt, now time.Time) int {
+	return int(now.Sub(followedAt) / (24 * time.Hour))
+}
+
+// narrowcastFilterObject returns the filter object of a narrowcast request as it was sent, or nil if it isn't set.
+func narrowcastFilterObject(ctx context.Context, filter *messagingapi.Filter) (json.RawMessage, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	if body := rawbody.GetBody(ctx); body != nil {
+		var request struct {
+			Filter json.RawMessage `json:"filter"`
+		}
+		if err := json.Unmarshal(body, &request); err == nil && request.Filter != nil {
+			return request.Filter, nil
+		}
+	}
+
+	object, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize filter: %w", err)
+	}
+	return object, nil
+}
+
+// parseDemographicFilter validates the filter object of a narrowcast request and returns its demographic filter.
+// It returns nil if no demographic filter is set.
+func parseDemographicFilter(filter json.RawMessage) (*demographicFilter, error) {
+	if len(filter) == 0 {
+		return nil, nil
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(filter, &obj); err != nil {
+		return nil, NewValidationError("The property, 'filter', in the request body is invalid")
+	}
+
+	v := newObjectValidator()
+	var result *demographicFilter
+	if demographic, ok := v.object(obj, "filter", "demographic"); ok {
+		result = v.demographicFilter(demographic, "filter.demographic")
+	}
+	if err := v.result(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// demographicFilter validates a demographic filter object and the filters nested in it
+func (v *objectValidator) demographicFilter(obj map[string]any, path string) *demographicFilter {
+	filterType, ok := v.requireEnum(obj, path, "type", "operator", "gender", "age", "appType", "area", "subscriptionPeriod")
+	if !ok {
+		return nil
+	}
+
+	filter := &demographicFilter{filterType: filterType}
+	switch filterType {
+	case "operator":
+		v.demographicOperator(obj, path, filter)
+	case "gender":
+		filter.oneOf = v.demographicOneOf(obj, path, demographicGenders, fmt.Sprintf("must be one of: %s", strings.Join(demographicGenders, ", ")))
+	case "appType":
+		filter.oneOf = v.demographicOneOf(obj, path, demographicAppTypes, fmt.Sprintf("must be one of: %s", strings.Join(demographicAppTypes, ", ")))
+	case "area":
+		filter.oneOf = v.demographicOneOf(obj, path, demographicAreas, "must be an area code, e.g. jp_13")
+	case "age":
+		filter.gte, filter.lt = v.demographicRange(obj, path, demographicAges, "age_")
+	case "subscriptionPeriod":
+		filter.gte, filter.lt = v.demographicRange(obj, path, demographicSubscriptionPeriods, "day_")
+	}
+	return filter
+}
+
+// demographicOperator validates an operator filter, which has exactly one of and, or and not
+func (v *objectValidator) demographicOperator(obj map[string]any, path string, filter *demographicFilter) {
+	var operators []string
+	for _, operator := range []string{"and", "or"} {
+		elements, ok := v.array(obj, path, operator, 1, maxDemographicFilterOperands)
+		if !ok {
+			continue
+		}
+		operators = append(operators, operator)
+		filter.operator = operator
+		for i, element := range elements {
+			operandPath := indexPath(propertyPath(path, operator), i)
+			operand, ok := v.elementObject(element, operandPath)
+			if !ok {
+				continue
+			}
+			filter.operands = append(filter.operands, v.demographicFilter(operand, operandPath))
+		}
+	}
+	if operand, ok := v.object(obj, path, "not"); ok {
+		operators = append(operators, "not")
+		filter.operator = "not"
+		filter.operands = []*demographicFilter{v.demographicFilter(operand, propertyPath(path, "not"))}
+	}
+
+	if len(operators) != 1 {
+		v.fail(path, "Exactly one of and, or and not must be specified")
+	}
+}
+
+// demographicOneOf validates the oneOf property of a filter on gender, appType or area
+func (v *objectValidator) demographicOneOf(obj map[string]any, path string, values []string, invalidMessage string) []string {
+	elements, ok := v.requireArray(obj, path, "oneOf", 1, len(values))
+	if !ok {
+		return nil
+	}
+
+	oneOf := make([]string, 0, len(elements))
+	for i, element := range elements {
+		s, ok := element.(string)
+		if !ok || !slices.Contains(values, s) {
+			v.fail(indexPath(propertyPath(path, "oneOf"), i), invalidMessage)
+			continue
+		}
+		oneOf = append(oneOf, s)
+	}
+	return oneOf
+}
+
+// demographicRange validates the gte and lt properties of a filter on age or subscriptionPeriod and returns their numbers
+func (v *objectValidator) demographicRange(obj map[string]any, path string, values []string, prefix string) (int, int) {
+	gteValue, hasGte := v.optionalEnum(obj, path, "gte", values...)
+	ltValue, hasLt := v.optionalEnum(obj, path, "lt", values...)
+	_, gteSet := obj["gte"]
+	_, ltSet := obj["lt"]
+	if !gteSet && !ltSet {
+		v.fail(path, "gte or lt must be specified")
+		return 0, 0
+	}
+
+	var gte, lt int
+	if hasGte {
+		gte, _ = strconv.Atoi(strings.TrimPrefix(gteValue, prefix))
+	}
+	if hasLt {
+		lt, _ = strconv.Atoi(strings.TrimPrefix(ltValue, prefix))
+	}
+	if hasGte && hasLt && gte >= lt {
+		v.fail(propertyPath(path, "lt"), "must be greater than gte")
+	}
+	return gte, lt
+}
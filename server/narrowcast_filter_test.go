@@ -0,0 +1,172 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+)
+
+func TestParseDemographicFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   []validationDetail
+	}{
+		{
+			name:   "without demographic filter",
+			filter: `{}`,
+		},
+		{
+			name: "nested operators",
+			filter: `{"demographic":{"type":"operator","and":[
+				{"type":"gender","oneOf":["female"]},
+				{"type":"age","gte":"age_20","lt":"age_40"},
+				{"type":"operator","not":{"type":"area","oneOf":["jp_13","tw_01"]}},
+				{"type":"operator","or":[{"type":"appType","oneOf":["ios"]},{"type":"subscriptionPeriod","gte":"day_30"}]}
+			]}}`,
+		},
+		{
+			name:   "unknown type",
+			filter: `{"demographic":{"type":"radius"}}`,
+			want: []validationDetail{
+				{"filter.demographic.type", "must be one of: operator, gender, age, appType, area, subscriptionPeriod"},
+			},
+		},
+		{
+			name:   "operator with both and and not",
+			filter: `{"demographic":{"type":"operator","and":[{"type":"gender","oneOf":["male"]}],"not":{"type":"appType","oneOf":["ios"]}}}`,
+			want: []validationDetail{
+				{"filter.demographic", "Exactly one of and, or and not must be specified"},
+			},
+		},
+		{
+			name:   "operator without operands",
+			filter: `{"demographic":{"type":"operator","or":[]}}`,
+			want: []validationDetail{
+				{"filter.demographic.or", "Size must be between 1 and 10"},
+				{"filter.demographic", "Exactly one of and, or and not must be specified"},
+			},
+		},
+		{
+			name:   "invalid values in nested filters",
+			filter: `{"demographic":{"type":"operator","or":[{"type":"gender","oneOf":["other"]},{"type":"area","oneOf":["jp_48"]},{"type":"appType"}]}}`,
+			want: []validationDetail{
+				{"filter.demographic.or[0].oneOf[0]", "must be one of: male, female"},
+				{"filter.demographic.or[1].oneOf[0]", "must be an area code, e.g. jp_13"},
+				{"filter.demographic.or[2].oneOf", "must be specified"},
+			},
+		},
+		{
+			name:   "age without bounds",
+			filter: `{"demographic":{"type":"age"}}`,
+			want: []validationDetail{
+				{"filter.demographic", "gte or lt must be specified"},
+			},
+		},
+		{
+			name:   "age with bounds in the wrong order",
+			filter: `{"demographic":{"type":"age","gte":"age_40","lt":"age_20"}}`,
+			want: []validationDetail{
+				{"filter.demographic.lt", "must be greater than gte"},
+			},
+		},
+		{
+			name:   "subscription period out of the options",
+			filter: `{"demographic":{"type":"subscriptionPeriod","lt":"day_14"}}`,
+			want: []validationDetail{
+				{"filter.demographic.lt", "must be one of: day_7, day_30, day_90, day_180, day_365"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDemographicFilter([]byte(tt.filter))
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr), "Expected ValidationError, got %v", err)
+			details := lo.Map(validationErr.Details, func(d messagingapi.ErrorDetail, _ int) validationDetail {
+				return validationDetail{lo.FromPtr(d.Property), lo.FromPtr(d.Message)}
+			})
+			assert.Equal(t, tt.want, details)
+		})
+	}
+}
+
+func TestDemographicFilterMatches(t *testing.T) {
+	alice := audienceMember{userID: "U_alice", gender: "female", age: 25, appType: "ios", area: "jp_13", subscriptionDays: 100}
+	bob := audienceMember{userID: "U_bob", gender: "male", age: 42, appType: "android", area: "jp_27", subscriptionDays: 3}
+	unknown := audienceMember{userID: "U_unknown", subscriptionDays: 30}
+	members := []audienceMember{alice, bob, unknown}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{
+			name:   "gender",
+			filter: `{"demographic":{"type":"gender","oneOf":["female"]}}`,
+			want:   []string{"U_alice"},
+		},
+		{
+			name:   "age range",
+			filter: `{"demographic":{"type":"age","gte":"age_20","lt":"age_30"}}`,
+			want:   []string{"U_alice"},
+		},
+		{
+			name:   "age lower bound",
+			filter: `{"demographic":{"type":"age","gte":"age_40"}}`,
+			want:   []string{"U_bob"},
+		},
+		{
+			name:   "area",
+			filter: `{"demographic":{"type":"area","oneOf":["jp_13","jp_27"]}}`,
+			want:   []string{"U_alice", "U_bob"},
+		},
+		{
+			name:   "subscription period",
+			filter: `{"demographic":{"type":"subscriptionPeriod","gte":"day_30"}}`,
+			want:   []string{"U_alice", "U_unknown"},
+		},
+		{
+			name:   "and",
+			filter: `{"demographic":{"type":"operator","and":[{"type":"appType","oneOf":["android"]},{"type":"subscriptionPeriod","lt":"day_7"}]}}`,
+			want:   []string{"U_bob"},
+		},
+		{
+			name:   "or",
+			filter: `{"demographic":{"type":"operator","or":[{"type":"gender","oneOf":["male"]},{"type":"appType","oneOf":["ios"]}]}}`,
+			want:   []string{"U_alice", "U_bob"},
+		},
+		{
+			name:   "not matches users whose attribute is unknown",
+			filter: `{"demographic":{"type":"operator","not":{"type":"gender","oneOf":["male"]}}}`,
+			want:   []string{"U_alice", "U_unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseDemographicFilter([]byte(tt.filter))
+			require.NoError(t, err)
+			matched := lo.FilterMap(members, func(m audienceMember, _ int) (string, bool) {
+				return m.userID, filter.matches(m)
+			})
+			assert.Equal(t, tt.want, matched)
+		})
+	}
+}
+
+func TestSubscriptionDays(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 0, subscriptionDays(now.Add(-time.Hour), now))
+	assert.Equal(t, 6, subscriptionDays(now.Add(-7*24*time.Hour+time.Minute), now))
+	assert.Equal(t, 7, subscriptionDays(now.Add(-7*24*time.Hour), now))
+}
@@ -0,0 +1,258 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+	"github.com/zero-color/line-messaging-api-emulator/internal/requestid"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestNarrowcast(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	sender := server.NewNarrowcastSender(srv)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	follow := func(t *testing.T, userID string, attributes adminapi.UserAttributes) {
+		t.Helper()
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+		_, err = srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: userID,
+		})
+		require.NoError(t, err)
+		resp, err := srv.SetUserAttributes(ctx, adminapi.SetUserAttributesRequestObject{
+			UserId: userID,
+			Body:   &attributes,
+		})
+		require.NoError(t, err)
+		require.IsType(t, adminapi.SetUserAttributes200JSONResponse{}, resp)
+	}
+	follow(t, "U_alice", adminapi.UserAttributes{Gender: lo.ToPtr(adminapi.GenderFemale), Age: lo.ToPtr(25), Area: lo.ToPtr("jp_13")})
+	follow(t, "U_bob", adminapi.UserAttributes{Gender: lo.ToPtr(adminapi.GenderMale), Age: lo.ToPtr(42), AppType: lo.ToPtr(adminapi.AppTypeAndroid)})
+	follow(t, "U_carol", adminapi.UserAttributes{Gender: lo.ToPtr(adminapi.GenderFemale), Age: lo.ToPtr(31)})
+
+	// narrowcast sends the message and returns the request ID of the API call
+	narrowcast := func(t *testing.T, body map[string]any, request *messagingapi.NarrowcastRequest) string {
+		t.Helper()
+		body["messages"] = []map[string]any{{"type": "text", "text": "Hello"}}
+		request.Messages = []messagingapi.Message{{Type: "text"}}
+		narrowcastCtx := withRequestBody(t, botCtx, body)
+		resp, err := srv.Narrowcast(narrowcastCtx, messagingapi.NarrowcastRequestObject{Body: request})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		require.NoError(t, resp.VisitNarrowcastResponse(rec))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		return requestid.GetRequestID(narrowcastCtx)
+	}
+	progress := func(t *testing.T, requestID string) messagingapi.GetNarrowcastProgress200JSONResponse {
+		t.Helper()
+		resp, err := srv.GetNarrowcastProgress(botCtx, messagingapi.GetNarrowcastProgressRequestObject{
+			Params: messagingapi.GetNarrowcastProgressParams{RequestId: requestID},
+		})
+		require.NoError(t, err)
+		p, ok := resp.(messagingapi.GetNarrowcastProgress200JSONResponse)
+		require.True(t, ok, "Expected progress, got %T", resp)
+		return p
+	}
+	recipients := func(t *testing.T, requestID string) []string {
+		t.Helper()
+		job, err := dbClient.GetNarrowcastJobByRequestID(ctx, db.GetNarrowcastJobByRequestIDParams{BotID: bot.ID, RequestID: requestID})
+		require.NoError(t, err)
+		deliveries, err := dbClient.ListMessageDeliveries(ctx, job.MessageID)
+		require.NoError(t, err)
+		return lo.Map(deliveries, func(d db.MessageDelivery, _ int) string { return d.RecipientID })
+	}
+
+	t.Run("sends to the users matching the demographic filter in the background", func(t *testing.T) {
+		filter := map[string]any{"demographic": map[string]any{
+			"type": "operator",
+			"and": []map[string]any{
+				{"type": "gender", "oneOf": []string{"female"}},
+				{"type": "age", "gte": "age_20", "lt": "age_30"},
+			},
+		}}
+		requestID := narrowcast(t, map[string]any{"filter": filter}, &messagingapi.NarrowcastRequest{
+			Filter: &messagingapi.Filter{},
+		})
+
+		p := progress(t, requestID)
+		assert.Equal(t, messagingapi.NarrowcastProgressResponsePhaseWaiting, p.Phase)
+		assert.Nil(t, p.TargetCount)
+
+		processed, err := sender.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+
+		p = progress(t, requestID)
+		assert.Equal(t, messagingapi.NarrowcastProgressResponsePhaseSucceeded, p.Phase)
+		assert.Equal(t, lo.ToPtr(int64(1)), p.TargetCount)
+		assert.Equal(t, lo.ToPtr(int64(1)), p.SuccessCount)
+		assert.Equal(t, lo.ToPtr(int64(0)), p.FailureCount)
+		assert.NotNil(t, p.CompletedTime)
+		assert.Equal(t, []string{"U_alice"}, recipients(t, requestID))
+	})
+
+	t.Run("limits the number of recipients", func(t *testing.T) {
+		requestID := narrowcast(t, map[string]any{"limit": map[string]any{"max": 2}}, &messagingapi.NarrowcastRequest{
+			Limit: &messagingapi.Limit{Max: lo.ToPtr(int32(2))},
+		})
+		_, err := sender.ProcessPending(ctx)
+		require.NoError(t, err)
+
+		p := progress(t, requestID)
+		assert.Equal(t, messagingapi.NarrowcastProgressResponsePhaseSucceeded, p.Phase)
+		assert.Equal(t, lo.ToPtr(int64(2)), p.SuccessCount)
+		assert.Len(t, recipients(t, requestID), 2)
+	})
+
+	t.Run("fails when no user matches the filter", func(t *testing.T) {
+		filter := map[string]any{"demographic": map[string]any{"type": "appType", "oneOf": []string{"ios"}}}
+		requestID := narrowcast(t, map[string]any{"filter": filter}, &messagingapi.NarrowcastRequest{
+			Filter: &messagingapi.Filter{},
+		})
+		_, err := sender.ProcessPending(ctx)
+		require.NoError(t, err)
+
+		p := progress(t, requestID)
+		assert.Equal(t, messagingapi.NarrowcastProgressResponsePhaseFailed, p.Phase)
+		assert.Equal(t, lo.ToPtr(int64(0)), p.TargetCount)
+		assert.Equal(t, lo.ToPtr(int64(2)), p.ErrorCode)
+		assert.NotNil(t, p.FailedDescription)
+	})
+
+	t.Run("measures subscription periods from when the users followed the bot", func(t *testing.T) {
+		later := server.NewNarrowcastSender(server.New(dbClient, server.WithClock(func() time.Time {
+			return time.Now().Add(40 * 24 * time.Hour)
+		})))
+		filter := map[string]any{"demographic": map[string]any{"type": "subscriptionPeriod", "gte": "day_30"}}
+		requestID := narrowcast(t, map[string]any{"filter": filter}, &messagingapi.NarrowcastRequest{
+			Filter: &messagingapi.Filter{},
+		})
+		_, err := later.ProcessPending(ctx)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"U_alice", "U_bob", "U_carol"}, recipients(t, requestID))
+	})
+
+	t.Run("rejects an invalid filter", func(t *testing.T) {
+		filter := map[string]any{"demographic": map[string]any{"type": "gender", "oneOf": []string{"unknown"}}}
+		body := map[string]any{"filter": filter, "messages": []map[string]any{{"type": "text", "text": "Hello"}}}
+		_, err := srv.Narrowcast(withRequestBody(t, botCtx, body), messagingapi.NarrowcastRequestObject{
+			Body: &messagingapi.NarrowcastRequest{
+				Filter:   &messagingapi.Filter{},
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		var validationErr *server.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "filter.demographic.oneOf[0]", lo.FromPtr(validationErr.Details[0].Property))
+	})
+
+	t.Run("accepts narrowcasts sent without a request ID", func(t *testing.T) {
+		for range 2 {
+			ctx := rawbody.SetBody(botCtx, []byte(`{"messages":[{"type":"text","text":"Hello"}]}`))
+			resp, err := srv.Narrowcast(ctx, messagingapi.NarrowcastRequestObject{
+				Body: &messagingapi.NarrowcastRequest{Messages: []messagingapi.Message{{Type: "text"}}},
+			})
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			require.NoError(t, resp.VisitNarrowcastResponse(rec))
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+		}
+	})
+
+	t.Run("returns not found for an unknown request ID", func(t *testing.T) {
+		resp, err := srv.GetNarrowcastProgress(botCtx, messagingapi.GetNarrowcastProgressRequestObject{
+			Params: messagingapi.GetNarrowcastProgressParams{RequestId: "unknown"},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, resp.VisitGetNarrowcastProgressResponse(rec))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestSetUserAttributes(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_alice",
+		DisplayName: "Alice",
+	})
+	require.NoError(t, err)
+
+	t.Run("replaces the attributes", func(t *testing.T) {
+		resp, err := srv.SetUserAttributes(ctx, adminapi.SetUserAttributesRequestObject{
+			UserId: "U_alice",
+			Body:   &adminapi.UserAttributes{Gender: lo.ToPtr(adminapi.GenderFemale), Area: lo.ToPtr("jp_13")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, adminapi.SetUserAttributes200JSONResponse{
+			UserId: "U_alice",
+			Gender: lo.ToPtr(adminapi.UserAttributesResponseGenderFemale),
+			Area:   lo.ToPtr("jp_13"),
+		}, resp)
+
+		resp, err = srv.SetUserAttributes(ctx, adminapi.SetUserAttributesRequestObject{
+			UserId: "U_alice",
+			Body:   &adminapi.UserAttributes{Age: lo.ToPtr(30)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, adminapi.SetUserAttributes200JSONResponse{
+			UserId: "U_alice",
+			Age:    lo.ToPtr(30),
+		}, resp)
+	})
+
+	t.Run("rejects an unknown area", func(t *testing.T) {
+		resp, err := srv.SetUserAttributes(ctx, adminapi.SetUserAttributesRequestObject{
+			UserId: "U_alice",
+			Body:   &adminapi.UserAttributes{Area: lo.ToPtr("jp_99")},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.SetUserAttributes400JSONResponse{}, resp)
+	})
+
+	t.Run("returns not found for an unknown user", func(t *testing.T) {
+		resp, err := srv.SetUserAttributes(ctx, adminapi.SetUserAttributesRequestObject{
+			UserId: "U_unknown",
+			Body:   &adminapi.UserAttributes{},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.SetUserAttributes404JSONResponse{}, resp)
+	})
+}
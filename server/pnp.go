@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+var (
+	// e164Pattern matches phone numbers in E.164 format, such as +818000001234
+	e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	// phoneNumberHashPattern matches hex encoded SHA-256 hashes, which PNP messages are addressed to
+	phoneNumberHashPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+)
+
+// hashPhoneNumber returns the hex encoded SHA-256 hash of a phone number in E.164 format
+func hashPhoneNumber(phoneNumber string) string {
+	sum := sha256.Sum256([]byte(phoneNumber))
+	return hex.EncodeToString(sum[:])
+}
+
+// enqueueDeliveryEvent queues the delivery completion event of a PNP message delivered to the user
+func (s *server) enqueueDeliveryEvent(ctx context.Context, botID int32, userID, deliveryTag string) error {
+	event := webhook.Event{
+		Type:           webhook.EventTypeDelivery,
+		Delivery:       &webhook.Delivery{Data: deliveryTag},
+		WebhookEventID: lineid.NewWebhookEventID(),
+		Timestamp:      time.Now().UnixMilli(),
+		Source: &webhook.Source{
+			Type:   webhook.SourceTypeUser,
+			UserID: userID,
+		},
+		Mode: webhook.ModeActive,
+	}
+	if err := webhook.Enqueue(ctx, s.db, botID, s.now(), event); err != nil {
+		return fmt.Errorf("failed to queue delivery event: %w", err)
+	}
+	return nil
+}
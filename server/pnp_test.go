@@ -0,0 +1,171 @@
+package server_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestPushMessagesByPhone(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	sentAt := time.Date(2024, 6, 14, 23, 30, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+	srv := server.New(dbClient, server.WithClock(func() time.Time { return sentAt }))
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	for _, userID := range []string{"U_alice", "U_bob"} {
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+	}
+
+	hash := func(phoneNumber string) string {
+		sum := sha256.Sum256([]byte(phoneNumber))
+		return hex.EncodeToString(sum[:])
+	}
+	pushByPhone := func(t *testing.T, to string, deliveryTag *string) (messagingapi.PushMessagesByPhoneResponseObject, error) {
+		t.Helper()
+		pushCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       to,
+			"messages": []map[string]any{{"type": "text", "text": "Your order has shipped"}},
+		})
+		return srv.PushMessagesByPhone(pushCtx, messagingapi.PushMessagesByPhoneRequestObject{
+			Params: messagingapi.PushMessagesByPhoneParams{XLineDeliveryTag: deliveryTag},
+			Body: &messagingapi.PnpMessagesRequest{
+				To:       to,
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+	}
+
+	t.Run("registers a phone number", func(t *testing.T) {
+		resp, err := srv.SetUserPhoneNumber(ctx, adminapi.SetUserPhoneNumberRequestObject{
+			UserId: "U_alice",
+			Body:   &adminapi.PhoneNumberRequest{PhoneNumber: "+818000001234"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, adminapi.SetUserPhoneNumber200JSONResponse{
+			UserId:          "U_alice",
+			PhoneNumber:     "+818000001234",
+			PhoneNumberHash: hash("+818000001234"),
+		}, resp)
+	})
+
+	t.Run("rejects a phone number which isn't in E.164 format", func(t *testing.T) {
+		resp, err := srv.SetUserPhoneNumber(ctx, adminapi.SetUserPhoneNumberRequestObject{
+			UserId: "U_bob",
+			Body:   &adminapi.PhoneNumberRequest{PhoneNumber: "080-0000-1234"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.SetUserPhoneNumber400JSONResponse{}, resp)
+	})
+
+	t.Run("rejects a phone number registered to another user", func(t *testing.T) {
+		resp, err := srv.SetUserPhoneNumber(ctx, adminapi.SetUserPhoneNumberRequestObject{
+			UserId: "U_bob",
+			Body:   &adminapi.PhoneNumberRequest{PhoneNumber: "+818000001234"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.SetUserPhoneNumber409JSONResponse{}, resp)
+	})
+
+	t.Run("delivers to the user with the hashed phone number without following the bot", func(t *testing.T) {
+		resp, err := pushByPhone(t, hash("+818000001234"), lo.ToPtr("order-0001-shipped"))
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.PushMessagesByPhone200Response{}, resp)
+
+		conversation, err := srv.GetConversation(ctx, adminapi.GetConversationRequestObject{
+			BotId:  createdBot.UserId,
+			ChatId: "U_alice",
+		})
+		require.NoError(t, err)
+		messages := conversation.(adminapi.GetConversation200JSONResponse).Messages
+		require.Len(t, messages, 1)
+		assert.Equal(t, "pnp", lo.FromPtr(messages[0].MessageType))
+		assert.Equal(t, "Your order has shipped", messages[0].Message["text"])
+
+		// The bot is notified of the delivery with the delivery tag
+		events, err := dbClient.ClaimWebhookEvents(ctx, db.ClaimWebhookEventsParams{
+			LeaseUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+			Now:        pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+			BatchSize:  10,
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		var event webhook.Event
+		require.NoError(t, json.Unmarshal(events[0].Payload, &event))
+		assert.Equal(t, webhook.EventTypeDelivery, event.Type)
+		assert.Equal(t, &webhook.Delivery{Data: "order-0001-shipped"}, event.Delivery)
+		assert.Equal(t, "U_alice", event.Source.UserID)
+	})
+
+	t.Run("fails to deliver to an unregistered phone number", func(t *testing.T) {
+		resp, err := pushByPhone(t, hash("+818000009999"), nil)
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.PushMessagesByPhone422JSONResponse{}, resp)
+	})
+
+	t.Run("rejects to which isn't a SHA-256 hash", func(t *testing.T) {
+		_, err := pushByPhone(t, "+818000001234", nil)
+		var validationErr *server.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "to", lo.FromPtr(validationErr.Details[0].Property))
+	})
+
+	t.Run("counts delivered messages by date", func(t *testing.T) {
+		statistics := func(t *testing.T, srv server.Server, date string) messagingapi.GetPNPMessageStatistics200JSONResponse {
+			t.Helper()
+			resp, err := srv.GetPNPMessageStatistics(botCtx, messagingapi.GetPNPMessageStatisticsRequestObject{
+				Params: messagingapi.GetPNPMessageStatisticsParams{Date: date},
+			})
+			require.NoError(t, err)
+			s, ok := resp.(messagingapi.GetPNPMessageStatistics200JSONResponse)
+			require.True(t, ok, "Expected GetPNPMessageStatistics200JSONResponse, got %T", resp)
+			return s
+		}
+
+		// The number of messages isn't available on the day they were sent
+		assert.Equal(t, messagingapi.Unready, statistics(t, srv, "20240614").Status)
+
+		nextDay := server.New(dbClient, server.WithClock(func() time.Time {
+			return sentAt.Add(time.Hour)
+		}))
+		s := statistics(t, nextDay, "20240614")
+		assert.Equal(t, messagingapi.Ready, s.Status)
+		assert.Equal(t, lo.ToPtr(int64(1)), s.Success)
+
+		_, err := srv.GetPNPMessageStatistics(botCtx, messagingapi.GetPNPMessageStatisticsRequestObject{
+			Params: messagingapi.GetPNPMessageStatisticsParams{Date: "2024-01-01"},
+		})
+		var validationErr *server.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
@@ -2,18 +2,96 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
 )
 
+// messageQuotaLimits is the number of messages a bot can send in a month with each plan.
+// Bots with the none plan can send any number of messages.
+var messageQuotaLimits = map[string]int64{
+	"free":     200,
+	"light":    5000,
+	"standard": 30000,
+}
+
+// quotaExceededMessage is returned with 429 when sending a message would exceed the monthly limit
+const quotaExceededMessage = "You have reached your monthly limit."
+
+// messageQuota is the monthly limit of a bot and the number of messages it has sent in the month
+type messageQuota struct {
+	limited  bool
+	limit    int64
+	consumed int64
+}
+
+// remaining returns how many more messages can be sent in the month
+func (q messageQuota) remaining() int64 {
+	return max(q.limit-q.consumed, 0)
+}
+
+// allows reports whether the message can be sent to the number of recipients within the monthly limit
+func (q messageQuota) allows(recipients int) bool {
+	return !q.limited || int64(recipients) <= q.remaining()
+}
+
+// startOfMonth returns the start of the calendar month of t in UTC+9
+func startOfMonth(t time.Time) time.Time {
+	year, month, _ := t.In(statisticsLocation).Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, statisticsLocation)
+}
+
+// messageQuota returns the monthly limit of the bot and the messages it has sent in the calendar month of now.
+// Each recipient of a push, multicast, narrowcast or broadcast message counts as one message; reply messages don't count.
+// A group or room a push message is sent to counts as one recipient because the members of chats aren't emulated.
+func (s *server) messageQuota(ctx context.Context, botID int32, now time.Time) (messageQuota, error) {
+	bot, err := s.db.GetBot(ctx, botID)
+	if err != nil {
+		return messageQuota{}, fmt.Errorf("failed to get bot: %w", err)
+	}
+
+	month := startOfMonth(now)
+	consumed, err := s.db.CountBotMessageQuotaConsumption(ctx, db.CountBotMessageQuotaConsumptionParams{
+		BotID:     botID,
+		SentFrom:  pgtype.Timestamptz{Time: month, Valid: true},
+		SentUntil: pgtype.Timestamptz{Time: month.AddDate(0, 1, 0), Valid: true},
+	})
+	if err != nil {
+		return messageQuota{}, fmt.Errorf("failed to count sent messages: %w", err)
+	}
+
+	limit, limited := messageQuotaLimits[bot.MessageQuotaPlan]
+	return messageQuota{
+		limited:  limited,
+		limit:    limit,
+		consumed: consumed,
+	}, nil
+}
+
 // GetMessageQuota gets the message quota
 func (s *server) GetMessageQuota(ctx context.Context, request messagingapi.GetMessageQuotaRequestObject) (messagingapi.GetMessageQuotaResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	quota, err := s.messageQuota(ctx, auth.GetBotID(ctx), s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !quota.limited {
+		return messagingapi.GetMessageQuota200JSONResponse{Type: messagingapi.None}, nil
+	}
+	return messagingapi.GetMessageQuota200JSONResponse{
+		Type:  messagingapi.Limited,
+		Value: &quota.limit,
+	}, nil
 }
 
 // GetMessageQuotaConsumption gets the message quota consumption
 func (s *server) GetMessageQuotaConsumption(ctx context.Context, request messagingapi.GetMessageQuotaConsumptionRequestObject) (messagingapi.GetMessageQuotaConsumptionResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
\ No newline at end of file
+	quota, err := s.messageQuota(ctx, auth.GetBotID(ctx), s.now())
+	if err != nil {
+		return nil, err
+	}
+	return messagingapi.GetMessageQuotaConsumption200JSONResponse{TotalUsage: quota.consumed}, nil
+}
@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/requestid"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestMessageQuota(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	// The messages are sent just before the end of the month in UTC+9, which quotas are counted in
+	sentAt := time.Date(2024, 6, 30, 23, 30, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+	srv := server.New(dbClient, server.WithClock(func() time.Time { return sentAt }))
+	sender := server.NewNarrowcastSender(srv)
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, adminapi.BotInfoResponseMessageQuotaPlanNone, createdBot.MessageQuotaPlan)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	_, err = srv.CreateFollowers(ctx, adminapi.CreateFollowersRequestObject{
+		BotId: createdBot.UserId,
+		Body:  &adminapi.CreateFollowersRequest{Count: 198},
+	})
+	require.NoError(t, err)
+	followers, err := dbClient.GetBotFollowers(ctx, db.GetBotFollowersParams{BotID: bot.ID, Limit: 3})
+	require.NoError(t, err)
+	followerIDs := lo.Map(followers, func(u db.User, _ int) string { return u.UserID })
+
+	consumption := func(t *testing.T, srv server.Server) int64 {
+		t.Helper()
+		resp, err := srv.GetMessageQuotaConsumption(botCtx, messagingapi.GetMessageQuotaConsumptionRequestObject{})
+		require.NoError(t, err)
+		return resp.(messagingapi.GetMessageQuotaConsumption200JSONResponse).TotalUsage
+	}
+	textMessage := []map[string]any{{"type": "text", "text": "Hello"}}
+
+	t.Run("has no limit by default", func(t *testing.T) {
+		resp, err := srv.GetMessageQuota(botCtx, messagingapi.GetMessageQuotaRequestObject{})
+		require.NoError(t, err)
+		assert.Equal(t, messagingapi.GetMessageQuota200JSONResponse{Type: messagingapi.None}, resp)
+	})
+
+	t.Run("sets the plan", func(t *testing.T) {
+		resp, err := srv.SetMessageQuotaPlan(ctx, adminapi.SetMessageQuotaPlanRequestObject{
+			BotId: createdBot.UserId,
+			Body:  &adminapi.SetMessageQuotaPlanRequest{Plan: adminapi.PlanFree},
+		})
+		require.NoError(t, err)
+		botResp, ok := resp.(adminapi.SetMessageQuotaPlan200JSONResponse)
+		require.True(t, ok, "Expected SetMessageQuotaPlan200JSONResponse, got %T", resp)
+		assert.Equal(t, adminapi.BotInfoResponseMessageQuotaPlanFree, botResp.MessageQuotaPlan)
+
+		quota, err := srv.GetMessageQuota(botCtx, messagingapi.GetMessageQuotaRequestObject{})
+		require.NoError(t, err)
+		assert.Equal(t, messagingapi.GetMessageQuota200JSONResponse{Type: messagingapi.Limited, Value: lo.ToPtr(int64(200))}, quota)
+
+		resp, err = srv.SetMessageQuotaPlan(ctx, adminapi.SetMessageQuotaPlanRequestObject{
+			BotId: createdBot.UserId,
+			Body:  &adminapi.SetMessageQuotaPlanRequest{Plan: "premium"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, adminapi.SetMessageQuotaPlan400JSONResponse{}, resp)
+	})
+
+	t.Run("counts each recipient of a broadcast message", func(t *testing.T) {
+		resp, err := srv.Broadcast(withRequestBody(t, botCtx, map[string]any{"messages": textMessage}), messagingapi.BroadcastRequestObject{
+			Body: &messagingapi.BroadcastRequest{Messages: []messagingapi.Message{{Type: "text"}}},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.Broadcast200JSONResponse{}, resp)
+		assert.Equal(t, int64(198), consumption(t, srv))
+	})
+
+	t.Run("rejects a multicast message exceeding the remaining quota", func(t *testing.T) {
+		resp, err := srv.Multicast(withRequestBody(t, botCtx, map[string]any{"to": followerIDs, "messages": textMessage}), messagingapi.MulticastRequestObject{
+			Body: &messagingapi.MulticastRequest{
+				To:       followerIDs,
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, messagingapi.Multicast429JSONResponse{Message: "You have reached your monthly limit."}, resp)
+		assert.Equal(t, int64(198), consumption(t, srv))
+	})
+
+	t.Run("narrows a narrowcast message down to the remaining quota", func(t *testing.T) {
+		narrowcastCtx := withRequestBody(t, botCtx, map[string]any{
+			"messages": textMessage,
+			"limit":    map[string]any{"upToRemainingQuota": true},
+		})
+		resp, err := srv.Narrowcast(narrowcastCtx, messagingapi.NarrowcastRequestObject{
+			Body: &messagingapi.NarrowcastRequest{
+				Messages: []messagingapi.Message{{Type: "text"}},
+				Limit:    &messagingapi.Limit{UpToRemainingQuota: lo.ToPtr(true)},
+			},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, resp.VisitNarrowcastResponse(rec))
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		_, err = sender.ProcessPending(ctx)
+		require.NoError(t, err)
+		progress, err := srv.GetNarrowcastProgress(botCtx, messagingapi.GetNarrowcastProgressRequestObject{
+			Params: messagingapi.GetNarrowcastProgressParams{RequestId: requestid.GetRequestID(narrowcastCtx)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, lo.ToPtr(int64(2)), progress.(messagingapi.GetNarrowcastProgress200JSONResponse).SuccessCount)
+		assert.Equal(t, int64(200), consumption(t, srv))
+	})
+
+	t.Run("rejects a push message once the quota is used up", func(t *testing.T) {
+		resp, err := srv.PushMessage(withRequestBody(t, botCtx, map[string]any{"to": followerIDs[0], "messages": textMessage}), messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To:       followerIDs[0],
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, messagingapi.PushMessage429JSONResponse{Message: "You have reached your monthly limit."}, resp)
+	})
+
+	t.Run("resets the consumption in the next month", func(t *testing.T) {
+		nextMonth := server.New(dbClient, server.WithClock(func() time.Time {
+			return sentAt.Add(time.Hour)
+		}))
+		assert.Equal(t, int64(0), consumption(t, nextMonth))
+
+		resp, err := nextMonth.PushMessage(withRequestBody(t, botCtx, map[string]any{"to": followerIDs[0], "messages": textMessage}), messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To:       followerIDs[0],
+				Messages: []messagingapi.Message{{Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, messagingapi.PushMessage200JSONResponse{}, resp)
+		assert.Equal(t, int64(1), consumption(t, nextMonth))
+		assert.Equal(t, int64(200), consumption(t, srv))
+	})
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/pgutil"
+)
+
+// retryKeyTTL is how long a retry key is kept after the request is accepted
+const retryKeyTTL = 24 * time.Hour
+
+// handleRetryKey looks up the request the bot sent with the same X-Line-Retry-Key within the last 24 hours.
+// It returns the retry key to store with the message, and the message of the accepted request if the request is a retry.
+func (s *server) handleRetryKey(ctx context.Context, botID int32, retryKey *uuid.UUID) (pgtype.UUID, *db.Message, error) {
+	if retryKey == nil {
+		return pgtype.UUID{}, nil, nil
+	}
+
+	retryKeyUUID := pgtype.UUID{Bytes: *retryKey, Valid: true}
+	accepted, err := s.db.GetMessagesByRetryKey(ctx, db.GetMessagesByRetryKeyParams{
+		BotID:         botID,
+		RetryKey:      retryKeyUUID,
+		AcceptedAfter: pgtype.Timestamptz{Time: s.now().Add(-retryKeyTTL), Valid: true},
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return retryKeyUUID, nil, fmt.Errorf("failed to get message by retry key: %w", err)
+		}
+		// Retry keys are unique per bot, so the retry key of an expired request is released to be used again
+		if err := s.db.ReleaseExpiredRetryKey(ctx, db.ReleaseExpiredRetryKeyParams{
+			BotID:         botID,
+			RetryKey:      retryKeyUUID,
+			AcceptedUntil: pgtype.Timestamptz{Time: s.now().Add(-retryKeyTTL), Valid: true},
+		}); err != nil {
+			return retryKeyUUID, nil, fmt.Errorf("failed to release expired retry key: %w", err)
+		}
+		return retryKeyUUID, nil, nil
+	}
+	return retryKeyUUID, &accepted, nil
+}
+
+// createMessage stores the message sent with the API call, and issues a message ID and a quote token for each of the count message objects.
+// The message and its sent messages are stored in a transaction so that a retried request never sees the message without them.
+// Concurrent requests with the same retry key can both pass handleRetryKey, but only one of them is stored
+// because retry keys are unique per bot; the others get the message of the accepted request.
+func (s *server) createMessage(ctx context.Context, params db.CreateMessageParams, count int) (db.Message, []messagingapi.SentMessage, *db.Message, error) {
+	var msg db.Message
+	var sentMessages []messagingapi.SentMessage
+	err := s.inTx(ctx, func(tx *server) error {
+		var err error
+		msg, err = tx.db.CreateMessage(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to store message: %w", err)
+		}
+		sentMessages, err = tx.createSentMessages(ctx, msg, count)
+		return err
+	})
+	if err == nil {
+		return msg, sentMessages, nil, nil
+	}
+	if !params.RetryKey.Valid || !pgutil.IsUniqueViolationError(err) {
+		return db.Message{}, nil, nil, err
+	}
+
+	accepted, err := s.db.GetMessagesByRetryKey(ctx, db.GetMessagesByRetryKeyParams{
+		BotID:         params.BotID,
+		RetryKey:      params.RetryKey,
+		AcceptedAfter: pgtype.Timestamptz{Time: s.now().Add(-retryKeyTTL), Valid: true},
+	})
+	if err != nil {
+		return db.Message{}, nil, nil, fmt.Errorf("failed to get message by retry key: %w", err)
+	}
+	return db.Message{}, nil, &accepted, nil
+}
+
+// retryKeyConflictResponse is returned when a request with the same retry key has already been accepted.
+// The request ID of the accepted request is returned in the x-line-accepted-request-id header,
+// and retried push requests get the sentMessages of the accepted request.
+type retryKeyConflictResponse struct {
+	acceptedRequestID string
+	sentMessages      *[]messagingapi.SentMessage
+}
+
+func newRetryKeyConflictResponse(accepted *db.Message) retryKeyConflictResponse {
+	return retryKeyConflictResponse{acceptedRequestID: lo.FromPtr(accepted.RequestID)}
+}
+
+// pushRetryKeyConflictResponse returns the response to a retried push request with the sentMessages of the accepted request
+func (s *server) pushRetryKeyConflictResponse(ctx context.Context, accepted *db.Message) (retryKeyConflictResponse, error) {
+	sentMessages, err := s.acceptedSentMessages(ctx, accepted)
+	if err != nil {
+		return retryKeyConflictResponse{}, err
+	}
+	response := newRetryKeyConflictResponse(accepted)
+	response.sentMessages = &sentMessages
+	return response, nil
+}
+
+func (response retryKeyConflictResponse) visit(w http.ResponseWriter) error {
+	w.Header().Set("X-Line-Accepted-Request-Id", response.acceptedRequestID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	return json.NewEncoder(w).Encode(messagingapi.ErrorResponse{
+		Message:      "The retry key is already accepted",
+		SentMessages: response.sentMessages,
+	})
+}
+
+func (response retryKeyConflictResponse) VisitPushMessageResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response retryKeyConflictResponse) VisitMulticastResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response retryKeyConflictResponse) VisitNarrowcastResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
+
+func (response retryKeyConflictResponse) VisitBroadcastResponse(w http.ResponseWriter) error {
+	return response.visit(w)
+}
@@ -0,0 +1,140 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestRetryKey(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	// createBotWithFollower creates a bot followed by a user
+	createBotWithFollower := func(t *testing.T, name string) context.Context {
+		t.Helper()
+		createdBot, botCtx := createBot(t, srv, dbClient, name)
+		_, err := srv.CreateFollowers(ctx, adminapi.CreateFollowersRequestObject{
+			BotId: createdBot.UserId,
+			Body:  &adminapi.CreateFollowersRequest{Count: 1},
+		})
+		require.NoError(t, err)
+		return botCtx
+	}
+	botCtx := createBotWithFollower(t, "Test Bot")
+	otherBotCtx := createBotWithFollower(t, "Other Bot")
+
+	followerID := func(t *testing.T, botCtx context.Context) string {
+		t.Helper()
+		followers, err := dbClient.GetBotFollowers(ctx, db.GetBotFollowersParams{BotID: auth.GetBotID(botCtx), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, followers, 1)
+		return followers[0].UserID
+	}
+
+	push := func(t *testing.T, botCtx context.Context, retryKey uuid.UUID) *httptest.ResponseRecorder {
+		t.Helper()
+		to := followerID(t, botCtx)
+		pushCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       to,
+			"messages": []map[string]any{{"type": "text", "text": "Hello"}, {"type": "text", "text": "World"}},
+		})
+		resp, err := srv.PushMessage(pushCtx, messagingapi.PushMessageRequestObject{
+			Params: messagingapi.PushMessageParams{XLineRetryKey: &retryKey},
+			Body: &messagingapi.PushMessageRequest{
+				To:       to,
+				Messages: []messagingapi.Message{{Type: "text"}, {Type: "text"}},
+			},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, resp.VisitPushMessageResponse(rec))
+		return rec
+	}
+
+	t.Run("answers a retried push with the accepted request", func(t *testing.T) {
+		retryKey := uuid.New()
+		rec := push(t, botCtx, retryKey)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var accepted messagingapi.PushMessageResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &accepted))
+		require.Len(t, accepted.SentMessages, 2)
+
+		rec = push(t, botCtx, retryKey)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("X-Line-Accepted-Request-Id"))
+		var conflict messagingapi.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &conflict))
+		assert.Equal(t, "The retry key is already accepted", conflict.Message)
+		require.NotNil(t, conflict.SentMessages)
+		assert.Equal(t, accepted.SentMessages, *conflict.SentMessages)
+
+		count, err := dbClient.CountBotMessages(ctx, auth.GetBotID(botCtx))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("scopes retry keys to the bot", func(t *testing.T) {
+		retryKey := uuid.New()
+		require.Equal(t, http.StatusOK, push(t, botCtx, retryKey).Code)
+		assert.Equal(t, http.StatusOK, push(t, otherBotCtx, retryKey).Code)
+	})
+
+	t.Run("answers a retried multicast which had no recipients with 409", func(t *testing.T) {
+		retryKey := uuid.New()
+		multicast := func() *httptest.ResponseRecorder {
+			resp, err := srv.Multicast(withRequestBody(t, botCtx, map[string]any{
+				"to":       []string{"U_unknown"},
+				"messages": []map[string]any{{"type": "text", "text": "Hello"}},
+			}), messagingapi.MulticastRequestObject{
+				Params: messagingapi.MulticastParams{XLineRetryKey: &retryKey},
+				Body: &messagingapi.MulticastRequest{
+					To:       []string{"U_unknown"},
+					Messages: []messagingapi.Message{{Type: "text"}},
+				},
+			})
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+			require.NoError(t, resp.VisitMulticastResponse(rec))
+			return rec
+		}
+		require.Equal(t, http.StatusOK, multicast().Code)
+
+		rec := multicast()
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("X-Line-Accepted-Request-Id"))
+	})
+
+	t.Run("answers a retried broadcast with the accepted request ID", func(t *testing.T) {
+		retryKey := uuid.New()
+		broadcast := func() (messagingapi.BroadcastResponseObject, error) {
+			return srv.Broadcast(withRequestBody(t, botCtx, map[string]any{
+				"messages": []map[string]any{{"type": "text", "text": "Hello"}},
+			}), messagingapi.BroadcastRequestObject{
+				Params: messagingapi.BroadcastParams{XLineRetryKey: &retryKey},
+				Body:   &messagingapi.BroadcastRequest{Messages: []messagingapi.Message{{Type: "text"}}},
+			})
+		}
+		_, err := broadcast()
+		require.NoError(t, err)
+
+		resp, err := broadcast()
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, resp.VisitBroadcastResponse(rec))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("X-Line-Accepted-Request-Id"))
+	})
+}
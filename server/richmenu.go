@@ -140,6 +140,13 @@ func (s *server) ValidateRichMenuBatchRequest(ctx context.Context, request messa
 
 // ValidateRichMenuObject validates a rich menu object
 func (s *server) ValidateRichMenuObject(ctx context.Context, request messagingapi.ValidateRichMenuObjectRequestObject) (messagingapi.ValidateRichMenuObjectResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	if request.Body == nil {
+		return nil, NewValidationError("Request body is required")
+	}
+
+	if err := validateRichMenu(ctx, request.Body); err != nil {
+		return nil, err
+	}
+
+	return messagingapi.ValidateRichMenuObject200Response{}, nil
 }
\ No newline at end of file
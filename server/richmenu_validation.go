@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+)
+
+const (
+	minRichMenuWidth          = 800
+	maxRichMenuWidth          = 2500
+	minRichMenuHeight         = 250
+	minRichMenuAspectRatio    = 1.45
+	maxRichMenuNameLength     = 300
+	maxRichMenuChatBarTextLen = 14
+	maxRichMenuAreas          = 20
+)
+
+// validateRichMenu validates a rich menu object and the actions of its areas
+func validateRichMenu(ctx context.Context, richMenu *messagingapi.RichMenuRequest) error {
+	body := rawbody.GetBody(ctx)
+	if body == nil {
+		var err error
+		if body, err = json.Marshal(richMenu); err != nil {
+			return fmt.Errorf("failed to serialize rich menu: %w", err)
+		}
+	}
+	var object map[string]any
+	if err := json.Unmarshal(body, &object); err != nil {
+		return NewValidationError("The request body is invalid")
+	}
+
+	v := newObjectValidator()
+	size, hasSize := v.validateRichMenuSize(object)
+	if selected, ok := object["selected"]; !ok || selected == nil {
+		v.fail("selected", "must be specified")
+	} else if _, ok := selected.(bool); !ok {
+		v.fail("selected", "must be a boolean")
+	}
+	v.requireString(object, "", "name", 1, maxRichMenuNameLength)
+	v.requireString(object, "", "chatBarText", 1, maxRichMenuChatBarTextLen)
+
+	// Areas can only be checked against the size when it is valid
+	var bounds *imageSize
+	if hasSize {
+		bounds = &size
+	}
+	areas, ok := v.requireArray(object, "", "areas", 0, maxRichMenuAreas)
+	if !ok {
+		return v.result()
+	}
+	for i, element := range areas {
+		areaPath := indexPath("areas", i)
+		area, ok := v.elementObject(element, areaPath)
+		if !ok {
+			continue
+		}
+		v.validateArea(area, areaPath, "bounds", bounds)
+		v.validateActionProperty(area, areaPath, "action", richMenuActionRules, true)
+	}
+	return v.result()
+}
+
+// validateRichMenuSize validates the size of the rich menu image
+func (v *objectValidator) validateRichMenuSize(object map[string]any) (imageSize, bool) {
+	size, ok := v.requireObject(object, "", "size")
+	if !ok {
+		return imageSize{}, false
+	}
+
+	width, hasWidth := v.requireInteger(size, "size", "width", minRichMenuWidth)
+	if hasWidth && width > maxRichMenuWidth {
+		v.fail("size.width", fmt.Sprintf("must be between %d and %d", minRichMenuWidth, maxRichMenuWidth))
+		hasWidth = false
+	}
+	height, hasHeight := v.requireInteger(size, "size", "height", minRichMenuHeight)
+	if !hasWidth || !hasHeight {
+		return imageSize{}, false
+	}
+	if float64(width)/float64(height) < minRichMenuAspectRatio {
+		v.fail("size", fmt.Sprintf("Aspect ratio (width / height) must be %v or more", minRichMenuAspectRatio))
+		return imageSize{}, false
+	}
+	return imageSize{width: width, height: height}, true
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
+)
+
+func TestValidateRichMenuObject(t *testing.T) {
+	s := &server{}
+
+	// validate validates the rich menu object as the raw request body and returns the details of the validation error
+	validate := func(t *testing.T, body string) []validationDetail {
+		t.Helper()
+		var richMenu messagingapi.RichMenuRequest
+		require.NoError(t, json.Unmarshal([]byte(body), &richMenu))
+		ctx := rawbody.SetBody(context.Background(), []byte(body))
+
+		response, err := s.ValidateRichMenuObject(ctx, messagingapi.ValidateRichMenuObjectRequestObject{Body: &richMenu})
+		if err == nil {
+			assert.IsType(t, messagingapi.ValidateRichMenuObject200Response{}, response)
+			return nil
+		}
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr), "Expected ValidationError, got %v", err)
+		details := make([]validationDetail, 0, len(validationErr.Details))
+		for _, d := range validationErr.Details {
+			details = append(details, validationDetail{lo.FromPtr(d.Property), lo.FromPtr(d.Message)})
+		}
+		return details
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []validationDetail
+	}{
+		{
+			name: "valid rich menu",
+			body: `{"size":{"width":2500,"height":1686},"selected":false,"name":"Rich menu","chatBarText":"Tap here","areas":[
+				{"bounds":{"x":0,"y":0,"width":1250,"height":1686},"action":{"type":"richmenuswitch","richMenuAliasId":"richmenu-alias-b","data":"richmenu-changed-to-b"}},
+				{"bounds":{"x":1250,"y":0,"width":1250,"height":1686},"action":{"type":"postback","data":"action=buy","inputOption":"closeRichMenu"}}
+			]}`,
+		},
+		{
+			name: "without required properties",
+			body: `{}`,
+			want: []validationDetail{
+				{"size", "must be specified"},
+				{"selected", "must be specified"},
+				{"name", "must be specified"},
+				{"chatBarText", "must be specified"},
+				{"areas", "must be specified"},
+			},
+		},
+		{
+			name: "invalid size",
+			body: `{"size":{"width":800,"height":800},"selected":true,"name":"Rich menu","chatBarText":"Tap here","areas":[]}`,
+			want: []validationDetail{{"size", "Aspect ratio (width / height) must be 1.45 or more"}},
+		},
+		{
+			name: "area out of bounds with invalid action",
+			body: `{"size":{"width":2500,"height":843},"selected":true,"name":"Rich menu","chatBarText":"Tap here","areas":[
+				{"bounds":{"x":0,"y":0,"width":2500,"height":1686},"action":{"type":"camera"}}
+			]}`,
+			want: []validationDetail{
+				{"areas[0].bounds", "must be within the height of the image (843)"},
+				{"areas[0].action.type", "must be one of: postback, message, uri, datetimepicker, richmenuswitch, clipboard"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validate(t, tt.body))
+		})
+	}
+}
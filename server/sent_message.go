@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/pkg/lineid"
+)
+
+// createSentMessages issues a message ID and a quote token for each message object sent with the message
+func (s *server) createSentMessages(ctx context.Context, msg db.Message, count int) ([]messagingapi.SentMessage, error) {
+	rows := make([]db.CreateSentMessagesParams, 0, count)
+	for i := range count {
+		rows = append(rows, db.CreateSentMessagesParams{
+			MessageID:     msg.ID,
+			BotID:         msg.BotID,
+			Position:      int32(i),
+			LineMessageID: lineid.NewMessageID(),
+			QuoteToken:    lineid.NewQuoteToken(),
+		})
+	}
+	if _, err := s.db.CreateSentMessages(ctx, rows); err != nil {
+		return nil, fmt.Errorf("failed to store sent messages: %w", err)
+	}
+
+	return lo.Map(rows, func(row db.CreateSentMessagesParams, _ int) messagingapi.SentMessage {
+		return messagingapi.SentMessage{Id: row.LineMessageID, QuoteToken: lo.ToPtr(row.QuoteToken)}
+	}), nil
+}
+
+// acceptedSentMessages returns the sentMessages of an accepted push request
+func (s *server) acceptedSentMessages(ctx context.Context, accepted *db.Message) ([]messagingapi.SentMessage, error) {
+	rows, err := s.db.ListSentMessages(ctx, accepted.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sent messages: %w", err)
+	}
+	return lo.Map(rows, func(row db.SentMessage, _ int) messagingapi.SentMessage {
+		return messagingapi.SentMessage{Id: row.LineMessageID, QuoteToken: lo.ToPtr(row.QuoteToken)}
+	}), nil
+}
+
+// validateQuoteTokens checks that the messages quote only messages the bot sent or received.
+// A quote token can't be used by other bots.
+func (s *server) validateQuoteTokens(ctx context.Context, botID int32, messages []json.RawMessage) error {
+	v := newObjectValidator()
+	for i, object := range messages {
+		var message struct {
+			Type       string `json:"type"`
+			QuoteToken string `json:"quoteToken"`
+		}
+		if err := json.Unmarshal(object, &message); err != nil || message.QuoteToken == "" {
+			continue
+		}
+		if message.Type != "text" && message.Type != "textV2" {
+			continue
+		}
+
+		exists, err := s.db.QuoteTokenExists(ctx, db.QuoteTokenExistsParams{
+			BotID:      botID,
+			QuoteToken: message.QuoteToken,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check quote token: %w", err)
+		}
+		if !exists {
+			v.fail(propertyPath(indexPath("messages", i), "quoteToken"), "Invalid quote token")
+		}
+	}
+	return v.result()
+}
@@ -0,0 +1,129 @@
+package server_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestQuoteToken(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	srv := server.New(dbClient)
+	ctx := context.Background()
+
+	createdBot, botCtx := createBot(t, srv, dbClient, "Test Bot")
+	otherBot, otherBotCtx := createBot(t, srv, dbClient, "Other Bot")
+
+	_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+		UserID:      "U_follower",
+		DisplayName: "Follower",
+	})
+	require.NoError(t, err)
+	for _, botID := range []string{createdBot.UserId, otherBot.UserId} {
+		_, err := srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  botID,
+			UserId: "U_follower",
+		})
+		require.NoError(t, err)
+	}
+
+	push := func(t *testing.T, botCtx context.Context, messages ...map[string]any) ([]messagingapi.SentMessage, error) {
+		t.Helper()
+		pushCtx := withRequestBody(t, botCtx, map[string]any{
+			"to":       "U_follower",
+			"messages": messages,
+		})
+		resp, err := srv.PushMessage(pushCtx, messagingapi.PushMessageRequestObject{
+			Body: &messagingapi.PushMessageRequest{
+				To: "U_follower",
+				Messages: lo.Map(messages, func(m map[string]any, _ int) messagingapi.Message {
+					return messagingapi.Message{Type: m["type"].(string)}
+				}),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		pushResp, ok := resp.(messagingapi.PushMessage200JSONResponse)
+		require.True(t, ok, "Expected PushMessage200JSONResponse, got %T", resp)
+		return pushResp.SentMessages, nil
+	}
+
+	t.Run("issues a numeric message ID and a quote token for each message object", func(t *testing.T) {
+		sent, err := push(t, botCtx,
+			map[string]any{"type": "text", "text": "Hello"},
+			map[string]any{"type": "sticker", "packageId": "446", "stickerId": "1988"},
+		)
+		require.NoError(t, err)
+		require.Len(t, sent, 2)
+		for _, m := range sent {
+			assert.Regexp(t, regexp.MustCompile(`^\d+$`), m.Id)
+			assert.NotEmpty(t, lo.FromPtr(m.QuoteToken))
+		}
+		assert.NotEqual(t, sent[0].Id, sent[1].Id)
+		assert.NotEqual(t, sent[0].QuoteToken, sent[1].QuoteToken)
+	})
+
+	t.Run("quotes a message sent by the bot", func(t *testing.T) {
+		sent, err := push(t, botCtx, map[string]any{"type": "text", "text": "Hello"})
+		require.NoError(t, err)
+
+		_, err = push(t, botCtx, map[string]any{"type": "text", "text": "Quoted", "quoteToken": *sent[0].QuoteToken})
+		assert.NoError(t, err)
+	})
+
+	t.Run("quotes a message received from a user", func(t *testing.T) {
+		resp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: "U_follower",
+			Body: &adminapi.SendUserMessageRequest{
+				Type: adminapi.Text,
+				Text: lo.ToPtr("Hello, bot"),
+			},
+		})
+		require.NoError(t, err)
+		sendResp, ok := resp.(adminapi.SendUserMessage202JSONResponse)
+		require.True(t, ok, "Expected SendUserMessage202JSONResponse, got %T", resp)
+
+		_, err = push(t, botCtx, map[string]any{"type": "text", "text": "Quoted", "quoteToken": lo.FromPtr(sendResp.QuoteToken)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a quote token of another bot", func(t *testing.T) {
+		sent, err := push(t, otherBotCtx, map[string]any{"type": "text", "text": "Hello"})
+		require.NoError(t, err)
+
+		_, err = push(t, botCtx,
+			map[string]any{"type": "text", "text": "Hello"},
+			map[string]any{"type": "text", "text": "Quoted", "quoteToken": *sent[0].QuoteToken},
+		)
+		var validationErr *server.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Len(t, validationErr.Details, 1)
+		assert.Equal(t, "messages[1].quoteToken", lo.FromPtr(validationErr.Details[0].Property))
+		assert.Equal(t, "Invalid quote token", lo.FromPtr(validationErr.Details[0].Message))
+	})
+
+	t.Run("rejects an unknown quote token", func(t *testing.T) {
+		count, err := dbClient.CountBotMessages(ctx, auth.GetBotID(botCtx))
+		require.NoError(t, err)
+
+		_, err = push(t, botCtx, map[string]any{"type": "text", "text": "Quoted", "quoteToken": "unknown"})
+		var validationErr *server.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+
+		// The message isn't sent
+		after, err := dbClient.CountBotMessages(ctx, auth.GetBotID(botCtx))
+		require.NoError(t, err)
+		assert.Equal(t, count, after)
+	})
+}
@@ -1,9 +1,14 @@
 package server
 
 import (
+	"context"
+	"time"
+
 	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
 	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/blobstore"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
 )
 
 type Server interface {
@@ -12,11 +17,60 @@ type Server interface {
 }
 
 type server struct {
-	db db.Querier
+	db            db.Querier
+	webhookClient *webhook.Client
+	replyTokenTTL time.Duration
+	now           func() time.Time
+	blobs         blobstore.Store
+}
+
+// DefaultReplyTokenTTL is how long reply tokens can be used after they are issued unless configured otherwise.
+const DefaultReplyTokenTTL = time.Minute
+
+// Option configures the server.
+type Option func(*server)
+
+// WithReplyTokenTTL sets how long reply tokens can be used after they are issued.
+func WithReplyTokenTTL(ttl time.Duration) Option {
+	return func(s *server) {
+		s.replyTokenTTL = ttl
+	}
 }
 
-func New(db db.Querier) Server {
-	return &server{
-		db: db,
+// WithClock sets the clock the current date of statistics, the expiry of loading animations, the progress of transcoding and the sending of narrowcast messages are determined with.
+func WithClock(now func() time.Time) Option {
+	return func(s *server) {
+		s.now = now
 	}
 }
+
+// WithBlobStore sets the store the content of media messages sent by users is kept in.
+// The content is kept in the database unless configured otherwise.
+func WithBlobStore(store blobstore.Store) Option {
+	return func(s *server) {
+		s.blobs = store
+	}
+}
+
+func New(db db.Querier, opts ...Option) Server {
+	s := &server{
+		db:            db,
+		webhookClient: webhook.NewClient(),
+		replyTokenTTL: DefaultReplyTokenTTL,
+		now:           time.Now,
+		blobs:         blobstore.NewDBStore(db),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// inTx runs fn with a copy of the server whose queries run in a transaction
+func (s *server) inTx(ctx context.Context, fn func(tx *server) error) error {
+	return db.InTx(ctx, s.db, func(q db.Querier) error {
+		tx := *s
+		tx.db = q
+		return fn(&tx)
+	})
+}
@@ -2,48 +2,110 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
 )
 
+// statisticsLocation is the time zone (UTC+9) the number of sent messages is aggregated by
+var statisticsLocation = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// statisticsStartDate is the date the LINE Platform started counting sent messages.
+// The number of messages sent before it is out of service.
+var statisticsStartDate = time.Date(2018, time.March, 31, 0, 0, 0, 0, statisticsLocation)
+
+// parseStatisticsDate parses the yyyyMMdd date of a statistics request and returns the start of the day in UTC+9
+func parseStatisticsDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation("20060102", date, statisticsLocation)
+	if err != nil {
+		return time.Time{}, NewValidationError("The value for the 'date' parameter is invalid")
+	}
+	return day, nil
+}
+
+// startOfDay returns the start of the day of t in UTC+9
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.In(statisticsLocation).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, statisticsLocation)
+}
+
+// numberOfSentMessages counts the messages of the type the bot sent on the yyyyMMdd date.
+// Each recipient counts as one message, and the number is available from the day after the messages were sent.
+func (s *server) numberOfSentMessages(ctx context.Context, messageType string, date string) (messagingapi.NumberOfMessagesResponse, error) {
+	day, err := parseStatisticsDate(date)
+	if err != nil {
+		return messagingapi.NumberOfMessagesResponse{}, err
+	}
+
+	if day.Before(statisticsStartDate) {
+		return messagingapi.NumberOfMessagesResponse{Status: messagingapi.OutOfService}, nil
+	}
+	// The number of messages sent on a day is available from the next day
+	if !day.Before(startOfDay(s.now())) {
+		return messagingapi.NumberOfMessagesResponse{Status: messagingapi.Unready}, nil
+	}
+
+	count, err := s.db.CountBotMessageDeliveries(ctx, db.CountBotMessageDeliveriesParams{
+		BotID:       auth.GetBotID(ctx),
+		MessageType: messageType,
+		SentFrom:    pgtype.Timestamptz{Time: day, Valid: true},
+		SentUntil:   pgtype.Timestamptz{Time: day.AddDate(0, 0, 1), Valid: true},
+	})
+	if err != nil {
+		return messagingapi.NumberOfMessagesResponse{}, fmt.Errorf("failed to count %s messages: %w", messageType, err)
+	}
+	return messagingapi.NumberOfMessagesResponse{
+		Status:  messagingapi.Ready,
+		Success: &count,
+	}, nil
+}
+
 // GetNumberOfSentBroadcastMessages gets the number of sent broadcast messages
 func (s *server) GetNumberOfSentBroadcastMessages(ctx context.Context, request messagingapi.GetNumberOfSentBroadcastMessagesRequestObject) (messagingapi.GetNumberOfSentBroadcastMessagesResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	response, err := s.numberOfSentMessages(ctx, "broadcast", request.Params.Date)
+	if err != nil {
+		return nil, err
+	}
+	return messagingapi.GetNumberOfSentBroadcastMessages200JSONResponse(response), nil
 }
 
 // GetNumberOfSentMulticastMessages gets the number of sent multicast messages
 func (s *server) GetNumberOfSentMulticastMessages(ctx context.Context, request messagingapi.GetNumberOfSentMulticastMessagesRequestObject) (messagingapi.GetNumberOfSentMulticastMessagesResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	response, err := s.numberOfSentMessages(ctx, "multicast", request.Params.Date)
+	if err != nil {
+		return nil, err
+	}
+	return messagingapi.GetNumberOfSentMulticastMessages200JSONResponse(response), nil
 }
 
 // GetNumberOfSentPushMessages gets the number of sent push messages
 func (s *server) GetNumberOfSentPushMessages(ctx context.Context, request messagingapi.GetNumberOfSentPushMessagesRequestObject) (messagingapi.GetNumberOfSentPushMessagesResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	response, err := s.numberOfSentMessages(ctx, "push", request.Params.Date)
+	if err != nil {
+		return nil, err
+	}
+	return messagingapi.GetNumberOfSentPushMessages200JSONResponse(response), nil
 }
 
 // GetNumberOfSentReplyMessages gets the number of sent reply messages
 func (s *server) GetNumberOfSentReplyMessages(ctx context.Context, request messagingapi.GetNumberOfSentReplyMessagesRequestObject) (messagingapi.GetNumberOfSentReplyMessagesResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	response, err := s.numberOfSentMessages(ctx, "reply", request.Params.Date)
+	if err != nil {
+		return nil, err
+	}
+	return messagingapi.GetNumberOfSentReplyMessages200JSONResponse(response), nil
 }
 
 // GetPNPMessageStatistics gets phone number push message statistics
+// Only successful deliveries are counted, as failed PNP messages aren't stored.
 func (s *server) GetPNPMessageStatistics(ctx context.Context, request messagingapi.GetPNPMessageStatisticsRequestObject) (messagingapi.GetPNPMessageStatisticsResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
+	response, err := s.numberOfSentMessages(ctx, "pnp", request.Params.Date)
+	if err != nil {
+		return nil, err
+	}
+	return messagingapi.GetPNPMessageStatistics200JSONResponse(response), nil
 }
-
-// GetAggregationUnitUsage gets aggregation unit usage
-func (s *server) GetAggregationUnitUsage(ctx context.Context, request messagingapi.GetAggregationUnitUsageRequestObject) (messagingapi.GetAggregationUnitUsageResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-// GetAggregationUnitNameList gets the list of aggregation unit names
-func (s *server) GetAggregationUnitNameList(ctx context.Context, request messagingapi.GetAggregationUnitNameListRequestObject) (messagingapi.GetAggregationUnitNameListResponseObject, error) {
-	//TODO implement me
-	panic("implement me")
-}
\ No newline at end of file
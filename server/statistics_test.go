@@ -0,0 +1,158 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zero-color/line-messaging-api-emulator/api/adminapi"
+	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/db"
+	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/server"
+)
+
+func TestNumberOfSentMessages(t *testing.T) {
+	dbClient := db.NewTestDB(t)
+	// The messages are sent just before midnight in UTC+9, which the days of statistics are in
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	sentAt := time.Date(2024, 6, 14, 23, 30, 0, 0, jst)
+	srv := server.New(dbClient, server.WithClock(func() time.Time { return sentAt }))
+	ctx := context.Background()
+
+	createResp, err := srv.CreateBot(ctx, adminapi.CreateBotRequestObject{
+		Body: &adminapi.CreateBotRequest{
+			DisplayName: "Test Bot",
+		},
+	})
+	require.NoError(t, err)
+	createdBot, ok := createResp.(adminapi.CreateBot201JSONResponse)
+	require.True(t, ok)
+
+	bot, err := dbClient.GetBotByUserID(ctx, createdBot.UserId)
+	require.NoError(t, err)
+	botCtx := auth.SetBotID(ctx, bot.ID)
+
+	for _, userID := range []string{"U_alice", "U_bob", "U_carol"} {
+		_, err := dbClient.CreateUser(ctx, db.CreateUserParams{
+			UserID:      userID,
+			DisplayName: userID,
+		})
+		require.NoError(t, err)
+		_, err = srv.FollowBot(ctx, adminapi.FollowBotRequestObject{
+			BotId:  createdBot.UserId,
+			UserId: userID,
+		})
+		require.NoError(t, err)
+	}
+
+	textMessage := []map[string]any{{"type": "text", "text": "Hello"}}
+	messages := []messagingapi.Message{{Type: "text"}}
+
+	_, err = srv.PushMessage(withRequestBody(t, botCtx, map[string]any{"to": "U_alice", "messages": textMessage}), messagingapi.PushMessageRequestObject{
+		Body: &messagingapi.PushMessageRequest{To: "U_alice", Messages: messages},
+	})
+	require.NoError(t, err)
+
+	multicastTo := []string{"U_alice", "U_bob"}
+	_, err = srv.Multicast(withRequestBody(t, botCtx, map[string]any{"to": multicastTo, "messages": textMessage}), messagingapi.MulticastRequestObject{
+		Body: &messagingapi.MulticastRequest{To: multicastTo, Messages: messages},
+	})
+	require.NoError(t, err)
+
+	_, err = srv.Broadcast(withRequestBody(t, botCtx, map[string]any{"messages": textMessage}), messagingapi.BroadcastRequestObject{
+		Body: &messagingapi.BroadcastRequest{Messages: messages},
+	})
+	require.NoError(t, err)
+
+	sendResp, err := srv.SendUserMessage(ctx, adminapi.SendUserMessageRequestObject{
+		BotId:  createdBot.UserId,
+		UserId: "U_carol",
+		Body: &adminapi.SendUserMessageRequest{
+			Type: adminapi.Text,
+			Text: lo.ToPtr("Hello, bot"),
+		},
+	})
+	require.NoError(t, err)
+	replyToken := sendResp.(adminapi.SendUserMessage202JSONResponse).ReplyToken
+	_, err = srv.ReplyMessage(withRequestBody(t, botCtx, map[string]any{"replyToken": replyToken, "messages": textMessage}), messagingapi.ReplyMessageRequestObject{
+		Body: &messagingapi.ReplyMessageRequest{ReplyToken: replyToken, Messages: messages},
+	})
+	require.NoError(t, err)
+
+	statistics := func(t *testing.T, srv server.Server, date string) map[string]messagingapi.NumberOfMessagesResponse {
+		t.Helper()
+		push, err := srv.GetNumberOfSentPushMessages(botCtx, messagingapi.GetNumberOfSentPushMessagesRequestObject{
+			Params: messagingapi.GetNumberOfSentPushMessagesParams{Date: date},
+		})
+		require.NoError(t, err)
+		reply, err := srv.GetNumberOfSentReplyMessages(botCtx, messagingapi.GetNumberOfSentReplyMessagesRequestObject{
+			Params: messagingapi.GetNumberOfSentReplyMessagesParams{Date: date},
+		})
+		require.NoError(t, err)
+		multicast, err := srv.GetNumberOfSentMulticastMessages(botCtx, messagingapi.GetNumberOfSentMulticastMessagesRequestObject{
+			Params: messagingapi.GetNumberOfSentMulticastMessagesParams{Date: date},
+		})
+		require.NoError(t, err)
+		broadcast, err := srv.GetNumberOfSentBroadcastMessages(botCtx, messagingapi.GetNumberOfSentBroadcastMessagesRequestObject{
+			Params: messagingapi.GetNumberOfSentBroadcastMessagesParams{Date: date},
+		})
+		require.NoError(t, err)
+		return map[string]messagingapi.NumberOfMessagesResponse{
+			"push":      messagingapi.NumberOfMessagesResponse(push.(messagingapi.GetNumberOfSentPushMessages200JSONResponse)),
+			"reply":     messagingapi.NumberOfMessagesResponse(reply.(messagingapi.GetNumberOfSentReplyMessages200JSONResponse)),
+			"multicast": messagingapi.NumberOfMessagesResponse(multicast.(messagingapi.GetNumberOfSentMulticastMessages200JSONResponse)),
+			"broadcast": messagingapi.NumberOfMessagesResponse(broadcast.(messagingapi.GetNumberOfSentBroadcastMessages200JSONResponse)),
+		}
+	}
+	t.Run("isn't ready on the day the messages were sent", func(t *testing.T) {
+		for messageType, s := range statistics(t, srv, "20240614") {
+			assert.Equal(t, messagingapi.Unready, s.Status, messageType)
+			assert.Nil(t, s.Success, messageType)
+		}
+	})
+
+	t.Run("counts each recipient from the next day", func(t *testing.T) {
+		nextDay := server.New(dbClient, server.WithClock(func() time.Time {
+			return sentAt.Add(time.Hour)
+		}))
+		ready := func(count int64) messagingapi.NumberOfMessagesResponse {
+			return messagingapi.NumberOfMessagesResponse{Status: messagingapi.Ready, Success: &count}
+		}
+		assert.Equal(t, map[string]messagingapi.NumberOfMessagesResponse{
+			"push":      ready(1),
+			"reply":     ready(1),
+			"multicast": ready(2),
+			"broadcast": ready(3),
+		}, statistics(t, nextDay, "20240614"))
+		assert.Equal(t, map[string]messagingapi.NumberOfMessagesResponse{
+			"push":      ready(0),
+			"reply":     ready(0),
+			"multicast": ready(0),
+			"broadcast": ready(0),
+		}, statistics(t, nextDay, "20240613"))
+		for messageType, s := range statistics(t, nextDay, "20240615") {
+			assert.Equal(t, messagingapi.Unready, s.Status, messageType)
+		}
+	})
+
+	t.Run("is out of service before March 31, 2018", func(t *testing.T) {
+		for messageType, s := range statistics(t, srv, "20180330") {
+			assert.Equal(t, messagingapi.OutOfService, s.Status, messageType)
+		}
+		for messageType, s := range statistics(t, srv, "20180331") {
+			assert.Equal(t, messagingapi.Ready, s.Status, messageType)
+		}
+	})
+
+	t.Run("rejects a date which isn't in the yyyyMMdd format", func(t *testing.T) {
+		_, err := srv.GetNumberOfSentPushMessages(botCtx, messagingapi.GetNumberOfSentPushMessagesRequestObject{
+			Params: messagingapi.GetNumberOfSentPushMessagesParams{Date: "2024-01-01"},
+		})
+		var validationErr *server.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
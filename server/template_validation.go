@@ -0,0 +1,146 @@
+package server
+
+const (
+	maxTemplateTitleLength           = 40
+	maxButtonsTextLength             = 160
+	maxConfirmTextLength             = 240
+	maxCarouselColumnTextLength      = 120
+	maxTemplateTextLengthWithHeading = 60
+	maxButtonsActions                = 4
+	maxCarouselColumnActions         = 3
+	maxCarouselColumns               = 10
+	maxTemplateImageURLLength        = 2000
+)
+
+// validateTemplateMessage validates a template message
+func (v *objectValidator) validateTemplateMessage(message map[string]any, path string) {
+	v.requireString(message, path, "altText", 1, maxAltTextLength)
+	template, ok := v.requireObject(message, path, "template")
+	if !ok {
+		return
+	}
+
+	templatePath := propertyPath(path, "template")
+	switch templateType, _ := v.requireEnum(template, templatePath, "type", "buttons", "confirm", "carousel", "image_carousel"); templateType {
+	case "buttons":
+		v.validateButtonsTemplate(template, templatePath)
+	case "confirm":
+		v.requireString(template, templatePath, "text", 1, maxConfirmTextLength)
+		v.validateTemplateActions(template, templatePath, 2, 2)
+	case "carousel":
+		v.validateCarouselTemplate(template, templatePath)
+	case "image_carousel":
+		v.validateImageCarouselTemplate(template, templatePath)
+	}
+}
+
+// validateButtonsTemplate validates a buttons template
+func (v *objectValidator) validateButtonsTemplate(template map[string]any, path string) {
+	v.validateTemplateImageSettings(template, path)
+	_, hasImage := v.optionalHTTPSURL(template, path, "thumbnailImageUrl", maxTemplateImageURLLength)
+	v.optionalColor(template, path, "imageBackgroundColor")
+	_, hasTitle := v.optionalString(template, path, "title", 1, maxTemplateTitleLength)
+
+	// The text is shorter when the template has an image or a title
+	maxText := maxButtonsTextLength
+	if hasImage || hasTitle {
+		maxText = maxTemplateTextLengthWithHeading
+	}
+	v.requireString(template, path, "text", 1, maxText)
+	v.validateTemplateDefaultAction(template, path)
+	v.validateTemplateActions(template, path, 1, maxButtonsActions)
+}
+
+// validateCarouselTemplate validates a carousel template and checks that its columns are consistent
+func (v *objectValidator) validateCarouselTemplate(template map[string]any, path string) {
+	v.validateTemplateImageSettings(template, path)
+	columns, ok := v.requireArray(template, path, "columns", 1, maxCarouselColumns)
+	if !ok {
+		return
+	}
+
+	// Every column must have the same number of actions and either all or none of the columns have images and titles
+	columnsPath := propertyPath(path, "columns")
+	var first map[string]bool
+	actionCounts := make([]int, 0, len(columns))
+	for i, element := range columns {
+		columnPath := indexPath(columnsPath, i)
+		column, ok := v.elementObject(element, columnPath)
+		if !ok {
+			continue
+		}
+
+		_, hasImage := v.optionalHTTPSURL(column, columnPath, "thumbnailImageUrl", maxTemplateImageURLLength)
+		v.optionalColor(column, columnPath, "imageBackgroundColor")
+		_, hasTitle := v.optionalString(column, columnPath, "title", 1, maxTemplateTitleLength)
+		maxText := maxCarouselColumnTextLength
+		if hasImage || hasTitle {
+			maxText = maxTemplateTextLengthWithHeading
+		}
+		v.requireString(column, columnPath, "text", 1, maxText)
+		v.validateTemplateDefaultAction(column, columnPath)
+		if actions, ok := v.validateTemplateActions(column, columnPath, 1, maxCarouselColumnActions); ok {
+			actionCounts = append(actionCounts, len(actions))
+		}
+
+		has := map[string]bool{"thumbnailImageUrl": hasImage, "title": hasTitle}
+		if first == nil {
+			first = has
+			continue
+		}
+		for _, key := range []string{"thumbnailImageUrl", "title"} {
+			if has[key] != first[key] {
+				v.fail(propertyPath(columnPath, key), "must be specified for all columns or none of them")
+			}
+		}
+	}
+	for _, count := range actionCounts {
+		if count != actionCounts[0] {
+			v.fail(columnsPath, "All columns must have the same number of actions")
+			break
+		}
+	}
+}
+
+// validateImageCarouselTemplate validates an image carousel template
+func (v *objectValidator) validateImageCarouselTemplate(template map[string]any, path string) {
+	columns, ok := v.requireArray(template, path, "columns", 1, maxCarouselColumns)
+	if !ok {
+		return
+	}
+	for i, element := range columns {
+		columnPath := indexPath(propertyPath(path, "columns"), i)
+		column, ok := v.elementObject(element, columnPath)
+		if !ok {
+			continue
+		}
+		v.requireHTTPSURL(column, columnPath, "imageUrl", maxTemplateImageURLLength)
+		v.validateActionProperty(column, columnPath, "action", imageCarouselActionRules, true)
+	}
+}
+
+// validateTemplateImageSettings validates how the images of a template are shown
+func (v *objectValidator) validateTemplateImageSettings(template map[string]any, path string) {
+	v.optionalEnum(template, path, "imageAspectRatio", "rectangle", "square")
+	v.optionalEnum(template, path, "imageSize", "cover", "contain")
+}
+
+// validateTemplateDefaultAction validates the action performed when the image, title or text area is tapped
+func (v *objectValidator) validateTemplateDefaultAction(obj map[string]any, path string) {
+	v.validateActionProperty(obj, path, "defaultAction", templateDefaultActionRules, false)
+}
+
+// validateTemplateActions checks that the number of actions is within the range and validates each action
+func (v *objectValidator) validateTemplateActions(obj map[string]any, path string, minActions, maxActions int) ([]any, bool) {
+	actions, ok := v.requireArray(obj, path, "actions", minActions, maxActions)
+	if !ok {
+		return actions, false
+	}
+	for i, element := range actions {
+		actionPath := indexPath(propertyPath(path, "actions"), i)
+		if action, ok := v.elementObject(element, actionPath); ok {
+			v.validateAction(action, actionPath, templateActionRules)
+		}
+	}
+	return actions, true
+}
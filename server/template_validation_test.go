@@ -0,0 +1,109 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTemplateMessage(t *testing.T) {
+	// templateMessage wraps the template in a template message
+	templateMessage := func(template string) string {
+		return fmt.Sprintf(`{"type":"template","altText":"Template","template":%s}`, template)
+	}
+	postback := `{"type":"postback","label":"Buy","data":"action=buy"}`
+	column := func(actions int) string {
+		return fmt.Sprintf(`{"title":"Item","text":"Description","actions":[%s]}`, strings.TrimSuffix(strings.Repeat(postback+",", actions), ","))
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		want    []validationDetail
+	}{
+		{
+			name:    "confirm template",
+			message: templateMessage(`{"type":"confirm","text":"Are you sure?","actions":[{"type":"message","label":"Yes","text":"yes"},{"type":"message","label":"No","text":"no"}]}`),
+		},
+		{
+			name:    "carousel template",
+			message: templateMessage(`{"type":"carousel","imageAspectRatio":"square","columns":[` + column(2) + `,` + column(2) + `]}`),
+		},
+		{
+			name:    "image carousel template",
+			message: templateMessage(`{"type":"image_carousel","columns":[{"imageUrl":"https://example.com/a.png","action":` + postback + `}]}`),
+		},
+		{
+			name:    "without altText and template",
+			message: `{"type":"template"}`,
+			want: []validationDetail{
+				{"messages[0].altText", "must be specified"},
+				{"messages[0].template", "must be specified"},
+			},
+		},
+		{
+			name:    "altText too long",
+			message: `{"type":"template","altText":"` + strings.Repeat("a", 1501) + `","template":{"type":"confirm","text":"Are you sure?","actions":[` + postback + `,` + postback + `]}}`,
+			want:    []validationDetail{{"messages[0].altText", "Length must be between 1 and 1500"}},
+		},
+		{
+			name:    "invalid template type",
+			message: templateMessage(`{"type":"list"}`),
+			want:    []validationDetail{{"messages[0].template.type", "must be one of: buttons, confirm, carousel, image_carousel"}},
+		},
+		{
+			name:    "buttons template with too many actions",
+			message: templateMessage(`{"type":"buttons","text":"Please select","actions":[` + strings.TrimSuffix(strings.Repeat(postback+",", 5), ",") + `]}`),
+			want:    []validationDetail{{"messages[0].template.actions", "Size must be between 1 and 4"}},
+		},
+		{
+			name:    "buttons template with title and long text",
+			message: templateMessage(`{"type":"buttons","title":"Menu","text":"` + strings.Repeat("a", 61) + `","actions":[` + postback + `]}`),
+			want:    []validationDetail{{"messages[0].template.text", "Length must be between 1 and 60"}},
+		},
+		{
+			name:    "buttons template with invalid image settings",
+			message: templateMessage(`{"type":"buttons","thumbnailImageUrl":"https://example.com/a.png","imageAspectRatio":"wide","imageSize":"fill","text":"Please select","actions":[` + postback + `]}`),
+			want: []validationDetail{
+				{"messages[0].template.imageAspectRatio", "must be one of: rectangle, square"},
+				{"messages[0].template.imageSize", "must be one of: cover, contain"},
+			},
+		},
+		{
+			name:    "confirm template with one action",
+			message: templateMessage(`{"type":"confirm","text":"Are you sure?","actions":[` + postback + `]}`),
+			want:    []validationDetail{{"messages[0].template.actions", "Size must be between 2 and 2"}},
+		},
+		{
+			name:    "carousel template with too many columns",
+			message: templateMessage(`{"type":"carousel","columns":[` + strings.TrimSuffix(strings.Repeat(column(1)+",", 11), ",") + `]}`),
+			want:    []validationDetail{{"messages[0].template.columns", "Size must be between 1 and 10"}},
+		},
+		{
+			name:    "carousel template with different numbers of actions",
+			message: templateMessage(`{"type":"carousel","columns":[` + column(1) + `,` + column(2) + `]}`),
+			want:    []validationDetail{{"messages[0].template.columns", "All columns must have the same number of actions"}},
+		},
+		{
+			name:    "carousel template with a title only in some columns",
+			message: templateMessage(`{"type":"carousel","columns":[` + column(1) + `,{"text":"Description","actions":[` + postback + `]}]}`),
+			want:    []validationDetail{{"messages[0].template.columns[1].title", "must be specified for all columns or none of them"}},
+		},
+		{
+			name:    "image carousel template without image URL and action",
+			message: templateMessage(`{"type":"image_carousel","columns":[{}]}`),
+			want: []validationDetail{
+				{"messages[0].template.columns[0].imageUrl", "must be specified"},
+				{"messages[0].template.columns[0].action", "must be specified"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validationDetails(t, tt.message))
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// objectValidator validates JSON objects sent in request bodies and collects the errors with the
+// property paths used by the LINE Platform, e.g. messages[0].contents.body.contents[1].text
+type objectValidator struct {
+	err *ValidationError
+}
+
+func newObjectValidator() *objectValidator {
+	return &objectValidator{err: NewValidationError("")}
+}
+
+// fail records an error for the property
+func (v *objectValidator) fail(property, message string) {
+	v.err.AddDetail(message, property)
+}
+
+// result returns the collected errors, or nil if the objects are valid
+func (v *objectValidator) result() error {
+	if len(v.err.Details) == 0 {
+		return nil
+	}
+	v.err.Message = fmt.Sprintf("The request body has %d error(s)", len(v.err.Details))
+	return v.err
+}
+
+// propertyPath returns the path of a property of the object at path, or the key itself for the request body
+func propertyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// indexPath returns the path of an element of the array at path
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// str returns the string property of the object. It fails if the property is set to another type.
+func (v *objectValidator) str(obj map[string]any, path, key string) (string, bool) {
+	value, ok := obj[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	s, ok := value.(string)
+	if !ok {
+		v.fail(propertyPath(path, key), "must be a string")
+		return "", false
+	}
+	return s, true
+}
+
+// requireString checks that the string property is set and its length is within the range
+func (v *objectValidator) requireString(obj map[string]any, path, key string, minLength, maxLength int) (string, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return "", false
+	}
+	return v.optionalString(obj, path, key, minLength, maxLength)
+}
+
+// optionalString checks the length of the string property if it is set
+func (v *objectValidator) optionalString(obj map[string]any, path, key string, minLength, maxLength int) (string, bool) {
+	s, ok := v.str(obj, path, key)
+	if !ok {
+		return "", false
+	}
+	if length := utf8.RuneCountInString(s); length < minLength || length > maxLength {
+		v.fail(propertyPath(path, key), fmt.Sprintf("Length must be between %d and %d", minLength, maxLength))
+		return s, false
+	}
+	return s, true
+}
+
+// requireEnum checks that the string property is set to one of the values
+func (v *objectValidator) requireEnum(obj map[string]any, path, key string, values ...string) (string, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return "", false
+	}
+	return v.optionalEnum(obj, path, key, values...)
+}
+
+// optionalEnum checks that the string property is set to one of the values if it is set
+func (v *objectValidator) optionalEnum(obj map[string]any, path, key string, values ...string) (string, bool) {
+	s, ok := v.str(obj, path, key)
+	if !ok {
+		return "", false
+	}
+	for _, value := range values {
+		if s == value {
+			return s, true
+		}
+	}
+	v.fail(propertyPath(path, key), fmt.Sprintf("must be one of: %s", strings.Join(values, ", ")))
+	return s, false
+}
+
+// requireHTTPSURL checks that the property is set to an HTTPS URL no longer than maxLength
+func (v *objectValidator) requireHTTPSURL(obj map[string]any, path, key string, maxLength int) (string, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return "", false
+	}
+	return v.optionalHTTPSURL(obj, path, key, maxLength)
+}
+
+// optionalHTTPSURL checks that the property is an HTTPS URL no longer than maxLength if it is set
+func (v *objectValidator) optionalHTTPSURL(obj map[string]any, path, key string, maxLength int) (string, bool) {
+	s, ok := v.optionalString(obj, path, key, 1, maxLength)
+	if !ok {
+		return s, false
+	}
+	if u, err := url.Parse(s); err != nil || u.Scheme != "https" || u.Host == "" {
+		v.fail(propertyPath(path, key), "must be an HTTPS URL")
+		return s, false
+	}
+	return s, true
+}
+
+// number returns the number property of the object. It fails if the property is set to another type.
+func (v *objectValidator) number(obj map[string]any, path, key string) (float64, bool) {
+	value, ok := obj[key]
+	if !ok || value == nil {
+		return 0, false
+	}
+	n, ok := value.(float64)
+	if !ok {
+		v.fail(propertyPath(path, key), "must be a number")
+		return 0, false
+	}
+	return n, true
+}
+
+// requireNumber checks that the number property is set and within the range
+func (v *objectValidator) requireNumber(obj map[string]any, path, key string, minValue, maxValue float64) (float64, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return 0, false
+	}
+	return v.optionalNumber(obj, path, key, minValue, maxValue)
+}
+
+// optionalNumber checks that the number property is within the range if it is set
+func (v *objectValidator) optionalNumber(obj map[string]any, path, key string, minValue, maxValue float64) (float64, bool) {
+	n, ok := v.number(obj, path, key)
+	if !ok {
+		return 0, false
+	}
+	if n < minValue || n > maxValue {
+		v.fail(propertyPath(path, key), fmt.Sprintf("must be between %v and %v", minValue, maxValue))
+		return n, false
+	}
+	return n, true
+}
+
+// integer returns the integer property of the object. It fails if the property is set to another type.
+func (v *objectValidator) integer(obj map[string]any, path, key string) (int, bool) {
+	n, ok := v.number(obj, path, key)
+	if !ok {
+		return 0, false
+	}
+	if n != float64(int(n)) {
+		v.fail(propertyPath(path, key), "must be an integer")
+		return 0, false
+	}
+	return int(n), true
+}
+
+// requireInteger checks that the integer property is set and is the minimum value or greater
+func (v *objectValidator) requireInteger(obj map[string]any, path, key string, minValue int) (int, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return 0, false
+	}
+	n, ok := v.integer(obj, path, key)
+	if !ok {
+		return 0, false
+	}
+	if n < minValue {
+		v.fail(propertyPath(path, key), fmt.Sprintf("must be %d or greater", minValue))
+		return n, false
+	}
+	return n, true
+}
+
+// object returns the object property. It fails if the property is set to another type.
+func (v *objectValidator) object(obj map[string]any, path, key string) (map[string]any, bool) {
+	value, ok := obj[key]
+	if !ok || value == nil {
+		return nil, false
+	}
+	o, ok := value.(map[string]any)
+	if !ok {
+		v.fail(propertyPath(path, key), "must be an object")
+		return nil, false
+	}
+	return o, true
+}
+
+// requireObject checks that the object property is set
+func (v *objectValidator) requireObject(obj map[string]any, path, key string) (map[string]any, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return nil, false
+	}
+	return v.object(obj, path, key)
+}
+
+// array returns the array property and checks that its size is within the range if it is set
+func (v *objectValidator) array(obj map[string]any, path, key string, minSize, maxSize int) ([]any, bool) {
+	value, ok := obj[key]
+	if !ok || value == nil {
+		return nil, false
+	}
+	a, ok := value.([]any)
+	if !ok {
+		v.fail(propertyPath(path, key), "must be an array")
+		return nil, false
+	}
+	if len(a) < minSize || len(a) > maxSize {
+		v.fail(propertyPath(path, key), fmt.Sprintf("Size must be between %d and %d", minSize, maxSize))
+		return a, false
+	}
+	return a, true
+}
+
+// requireArray checks that the array property is set and its size is within the range
+func (v *objectValidator) requireArray(obj map[string]any, path, key string, minSize, maxSize int) ([]any, bool) {
+	if _, ok := obj[key]; !ok || obj[key] == nil {
+		v.fail(propertyPath(path, key), "must be specified")
+		return nil, false
+	}
+	return v.array(obj, path, key, minSize, maxSize)
+}
+
+// elementObject returns the array element as an object. It fails if the element is another type.
+func (v *objectValidator) elementObject(element any, path string) (map[string]any, bool) {
+	o, ok := element.(map[string]any)
+	if !ok {
+		v.fail(path, "must be an object")
+		return nil, false
+	}
+	return o, true
+}
+
+// imageSize is the size of an image which tappable areas are placed on
+type imageSize struct {
+	width  int
+	height int
+}
+
+// validateArea checks that the area property is set and lies within the image if its size is known
+func (v *objectValidator) validateArea(obj map[string]any, path, key string, size *imageSize) {
+	area, ok := v.requireObject(obj, path, key)
+	if !ok {
+		return
+	}
+
+	areaPath := propertyPath(path, key)
+	x, hasX := v.requireInteger(area, areaPath, "x", 0)
+	y, hasY := v.requireInteger(area, areaPath, "y", 0)
+	width, hasWidth := v.requireInteger(area, areaPath, "width", 1)
+	height, hasHeight := v.requireInteger(area, areaPath, "height", 1)
+	if size == nil {
+		return
+	}
+	if hasX && hasWidth && x+width > size.width {
+		v.fail(areaPath, fmt.Sprintf("must be within the width of the image (%d)", size.width))
+	}
+	if hasY && hasHeight && y+height > size.height {
+		v.fail(areaPath, fmt.Sprintf("must be within the height of the image (%d)", size.height))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
+	"github.com/zero-color/line-messaging-api-emulator/internal/rawbody"
 )
 
 func TestValidationEndpointHTTPResponse(t *testing.T) {
@@ -69,20 +70,15 @@ func TestValidationEndpointHTTPResponse(t *testing.T) {
 
 	t.Run("returns 200 for valid messages", func(t *testing.T) {
 		// Create request with valid data
-		requestBody := messagingapi.ValidateMessageRequest{
-			Messages: []messagingapi.Message{
-				{Type: "text"},
-			},
-		}
-		body, _ := json.Marshal(requestBody)
+		body := []byte(`{"messages":[{"type":"text","text":"Hello, world"}]}`)
 		
 		req := httptest.NewRequest(http.MethodPost, "/v2/bot/message/validate/broadcast", bytes.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
 		
 		w := httptest.NewRecorder()
 		
-		// Use the handler directly
-		handler.ValidateBroadcast(w, req)
+		// Use the handler with the raw body middleware in the same way as the server does
+		rawbody.Middleware(http.HandlerFunc(handler.ValidateBroadcast)).ServeHTTP(w, req)
 		
 		// Check response
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -1,19 +1,18 @@
 package server
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
 	"github.com/zero-color/line-messaging-api-emulator/db"
 	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
 )
 
 // GetWebhookEndpoint gets the webhook endpoint URL
@@ -121,30 +120,13 @@ func (s *server) TestWebhookEndpoint(ctx context.Context, request messagingapi.T
 
 	payloadBytes, _ := json.Marshal(testPayload)
 
-	// Make a POST request to the webhook URL
-	client := &http.Client{
-		Timeout: 5 * time.Second,
+	// Make a signed POST request to the webhook URL and keep it in the delivery log
+	result := s.webhookClient.Send(ctx, parsedURL.String(), bot.ChannelSecret, payloadBytes)
+	if err := webhook.RecordDelivery(ctx, s.db, bot.ID, nil, parsedURL.String(), payloadBytes, result); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", parsedURL.String(), bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		falseVal := false
-		timestamp := time.Now()
-		return messagingapi.TestWebhookEndpoint200JSONResponse{
-			Success:    &falseVal,
-			Timestamp:  timestamp,
-			StatusCode: 0,
-			Reason:     "Request creation failed",
-			Detail:     fmt.Sprintf("Failed to create request: %v", err),
-		}, nil
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Line-Signature", "test-signature")
-	req.Header.Set("User-Agent", "LineBotWebhook/2.0")
-
-	resp, err := client.Do(req)
-	if err != nil {
+	if result.Err != nil {
 		falseVal := false
 		timestamp := time.Now()
 		return messagingapi.TestWebhookEndpoint200JSONResponse{
@@ -152,19 +134,18 @@ func (s *server) TestWebhookEndpoint(ctx context.Context, request messagingapi.T
 			Timestamp:  timestamp,
 			StatusCode: 0,
 			Reason:     "Connection failed",
-			Detail:     fmt.Sprintf("Failed to connect to webhook: %v", err),
+			Detail:     result.Err.Error(),
 		}, nil
 	}
-	defer resp.Body.Close()
 
-	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	success := result.Success()
 	timestamp := time.Now()
 
 	return messagingapi.TestWebhookEndpoint200JSONResponse{
 		Success:    &success,
 		Timestamp:  timestamp,
-		StatusCode: int32(resp.StatusCode),
-		Reason:     resp.Status,
-		Detail:     fmt.Sprintf("%d", resp.StatusCode),
+		StatusCode: int32(result.StatusCode),
+		Reason:     result.Status,
+		Detail:     fmt.Sprintf("%d", result.StatusCode),
 	}, nil
 }
@@ -3,6 +3,7 @@ package server_test
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/zero-color/line-messaging-api-emulator/api/messagingapi"
 	"github.com/zero-color/line-messaging-api-emulator/db"
 	"github.com/zero-color/line-messaging-api-emulator/internal/auth"
+	"github.com/zero-color/line-messaging-api-emulator/internal/webhook"
 	"github.com/zero-color/line-messaging-api-emulator/server"
 )
 
@@ -255,11 +257,14 @@ func TestTestWebhookEndpoint(t *testing.T) {
 		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			assert.Equal(t, "POST", r.Method)
 			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
-			assert.Equal(t, "test-signature", r.Header.Get("X-Line-Signature"))
 			assert.Equal(t, "LineBotWebhook/2.0", r.Header.Get("User-Agent"))
 
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, webhook.Sign(createdBot.ChannelSecret, body), r.Header.Get("X-Line-Signature"))
+
 			var payload map[string]interface{}
-			err := json.NewDecoder(r.Body).Decode(&payload)
+			err = json.Unmarshal(body, &payload)
 			require.NoError(t, err)
 			assert.Equal(t, createdBot.UserId, payload["destination"])
 